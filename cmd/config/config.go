@@ -15,6 +15,13 @@ type Config struct {
 	Logger     LoggerConfig
 	Jaeger     JaegerConfig
 	UserServer UserServerConfig
+	Publisher  PublisherConfig
+	Trash      TrashConfig
+	Roles      RolesConfig
+	Comment    CommentConfig
+	Reactions  ReactionsConfig
+	Reconcile  ReconcileConfig
+	Views      ViewsConfig
 }
 
 // Server config struct
@@ -42,6 +49,58 @@ type UserServerConfig struct {
 	Address string
 }
 
+// PublisherConfig scheduled articles publisher config
+type PublisherConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// TrashConfig deleted articles retention config
+type TrashConfig struct {
+	RetentionDays int
+	Interval      time.Duration
+	BatchSize     int
+}
+
+// ReconcileConfig denormalized counters reconciliation config,
+// the periodic reconciliation is disabled without an interval
+type ReconcileConfig struct {
+	Interval  time.Duration
+	BatchSize int
+	// Fix updates the drifted counters, they are only reported otherwise
+	Fix bool
+}
+
+// ViewsConfig article views counting config
+type ViewsConfig struct {
+	// Window is the time in minutes a viewer is counted once per article
+	Window        time.Duration
+	FlushInterval time.Duration
+	// MaxViewers is the number of viewers remembered for the window, the least recent are forgotten first
+	MaxViewers int
+}
+
+// RolesConfig user ids with elevated permissions
+type RolesConfig struct {
+	Admins     []string
+	Moderators []string
+}
+
+// CommentConfig comments config
+type CommentConfig struct {
+	// MaxDepth is the deepest nesting level of replies
+	MaxDepth int32
+	// Tombstones keeps deleted comments with replies as placeholders, without it the replies
+	// are deleted along with the comment when a moderator deletes it
+	Tombstones bool
+}
+
+// ReactionsConfig reactions config
+type ReactionsConfig struct {
+	// Names are the reactions users can react with
+	Names []string
+}
+
 // Logger config
 type LoggerConfig struct {
 	Development       bool
@@ -7,13 +7,107 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/rezaAmiri123/service-user/cmd/config"
 	pb "github.com/rezaAmiri123/service-article/gen/pb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
+// headerMatcher forwards If-Match to the server for conditional updates
+func headerMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == "If-Match" {
+		return "if-match", true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// errorHandler reports failed preconditions, e.g. a stale If-Match, as 412
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, m runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	// an error after httpResponseModifier must not be sent with the status of the response
+	w.Header().Del(httpCodeHeader)
+	if status.Code(err) == codes.FailedPrecondition {
+		w = &statusResponseWriter{ResponseWriter: w, code: http.StatusPreconditionFailed}
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+}
+
+// statusResponseWriter overrides the status code written to the response
+type statusResponseWriter struct {
+	http.ResponseWriter
+	code int
+}
+
+func (w *statusResponseWriter) WriteHeader(int) {
+	w.ResponseWriter.WriteHeader(w.code)
+}
+
+// httpCodeHeader passes the http status code from httpResponseModifier to deferredStatusWriter
+const httpCodeHeader = "X-Http-Code"
+
+// deferredStatusWriter writes the status code left by httpResponseModifier in the header
+// once the gateway writes the response, so the headers it sets after the forward
+// response options, like the content type, are not dropped by an early WriteHeader
+type deferredStatusWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *deferredStatusWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if val := w.Header().Get(httpCodeHeader); val != "" {
+		w.Header().Del(httpCodeHeader)
+		if c, err := strconv.Atoi(val); err == nil {
+			code = c
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *deferredStatusWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// withDeferredStatus lets the forward response options set the status code
+func withDeferredStatus(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(&deferredStatusWriter{ResponseWriter: w}, r)
+	})
+}
+
+// httpResponseModifier applies the http status code and location
+// sent by the server as response header metadata
+func httpResponseModifier(ctx context.Context, w http.ResponseWriter, resp proto.Message) error {
+	if a, ok := resp.(*pb.Article); ok && a.GetVersion() > 0 {
+		w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(a.GetVersion(), 10)))
+	}
+	md, ok := runtime.ServerMetadataFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if vals := md.HeaderMD.Get("location"); len(vals) > 0 {
+		w.Header().Set("Location", vals[0])
+	}
+	if vals := md.HeaderMD.Get("x-http-code"); len(vals) > 0 {
+		if _, err := strconv.Atoi(vals[0]); err != nil {
+			return err
+		}
+		w.Header().Del("Grpc-Metadata-X-Http-Code")
+		w.Header().Set(httpCodeHeader, vals[0])
+	}
+	return nil
+}
+
 func run(cfg *config.Config) error {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
@@ -21,6 +115,9 @@ func run(cfg *config.Config) error {
 
 	ropts := []runtime.ServeMuxOption{
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{}),
+		runtime.WithForwardResponseOption(httpResponseModifier),
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithErrorHandler(errorHandler),
 	}
 
 	mux := runtime.NewServeMux(ropts...)
@@ -31,7 +128,7 @@ func run(cfg *config.Config) error {
 		return err
 	}
 	log.Printf("starting gateway server on port %v", cfg.Gateway.Port)
-	return http.ListenAndServe(cfg.Gateway.Port, mux)
+	return http.ListenAndServe(cfg.Gateway.Port, withDeferredStatus(mux))
 }
 
 func main() {
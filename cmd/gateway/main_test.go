@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+)
+
+func TestRedirectResponse(t *testing.T) {
+	mux := runtime.NewServeMux(runtime.WithForwardResponseOption(httpResponseModifier))
+	md := runtime.ServerMetadata{HeaderMD: metadata.Pairs("location", "/articles/new-slug", "x-http-code", "301")}
+	ctx := runtime.NewServerMetadataContext(context.Background(), md)
+	req := httptest.NewRequest(http.MethodGet, "/articles/old-slug", nil)
+	rec := httptest.NewRecorder()
+
+	w := &deferredStatusWriter{ResponseWriter: rec}
+	runtime.ForwardResponseMessage(ctx, mux, &runtime.JSONPb{}, w, req, &pb.Article{Slug: "new-slug", Version: 2},
+		httpResponseModifier)
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMovedPermanently)
+	}
+	for header, want := range map[string]string{
+		"Location":     "/articles/new-slug",
+		"Content-Type": "application/json",
+		"Etag":         `"2"`,
+		httpCodeHeader: "",
+	} {
+		if got := rec.Header().Get(header); got != want {
+			t.Errorf("header %s = %q, want %q", header, got, want)
+		}
+	}
+	if rec.Body.Len() == 0 {
+		t.Errorf("empty body")
+	}
+}
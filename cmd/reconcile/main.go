@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/rezaAmiri123/service-article/cmd/config"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	"github.com/rezaAmiri123/service-article/internal/worker"
+	"github.com/rezaAmiri123/service-article/pkg/logger"
+	"github.com/rezaAmiri123/service-article/pkg/mysql"
+	"github.com/rezaAmiri123/service-article/pkg/utils"
+)
+
+// reconcile recomputes the denormalized article counters from their source tables
+// and reports the drifted ones, they are only fixed with -fix
+func main() {
+	fix := flag.Bool("fix", false, "update the drifted counters, they are only reported otherwise")
+	batchSize := flag.Int("batch-size", 0, "number of articles checked per batch")
+	flag.Parse()
+
+	configPath := utils.GetConfigPath(os.Getenv("config"))
+	cfg, err := config.GetConfig(configPath)
+	if err != nil {
+		log.Fatalf("Loading config: %v", err)
+	}
+	cfg.Reconcile.Fix = *fix
+	if *batchSize > 0 {
+		cfg.Reconcile.BatchSize = *batchSize
+	}
+
+	appLogger := logger.NewAPILogger(cfg)
+	appLogger.InitLogger()
+
+	db := mysql.NewGormDB(cfg)
+	defer db.Close()
+
+	repo := repository.NewORMArticleRepository(db)
+	drifts, err := worker.NewReconciler(cfg, repo, appLogger).Reconcile(context.Background())
+	if err != nil {
+		appLogger.Fatalf("failed to reconcile counters: %v", err)
+	}
+	if len(drifts) == 0 {
+		appLogger.Info("all counters are consistent")
+		return
+	}
+	if !*fix {
+		appLogger.Infof("dry run, %d drifted counters were not fixed, run with -fix to fix them", len(drifts))
+	}
+}
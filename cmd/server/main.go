@@ -1,9 +1,13 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/opentracing/opentracing-go"
@@ -15,6 +19,7 @@ import (
 	"github.com/rezaAmiri123/service-article/internal/handler"
 	"github.com/rezaAmiri123/service-article/internal/model"
 	"github.com/rezaAmiri123/service-article/internal/repository"
+	"github.com/rezaAmiri123/service-article/internal/worker"
 	"github.com/rezaAmiri123/service-article/pkg/jaeger"
 	"github.com/rezaAmiri123/service-article/pkg/logger"
 	"github.com/rezaAmiri123/service-article/pkg/mysql"
@@ -56,6 +61,11 @@ func main() {
 	appLogger.Info("Opentracing connected")
 
 	repo := repository.NewORMArticleRepository(db)
+	tagRepo := repository.NewORMTagRepository(db)
+	reportRepo := repository.NewORMReportRepository(db)
+	reactionRepo := repository.NewORMReactionRepository(db)
+	bookmarkRepo := repository.NewORMBookmarkRepository(db)
+	views := worker.NewViewCounter(cfg, repo, appLogger)
 
 	var conn *grpc.ClientConn
 	conn, err = grpc.Dial(cfg.UserServer.Address, grpc.WithInsecure())
@@ -65,7 +75,7 @@ func main() {
 	defer conn.Close()
 	userConn := userPb.NewUsersClient(conn)
 
-	h := handler.NewArticleHandler(repo, appLogger, userConn)
+	h := handler.NewArticleHandler(cfg, repo, tagRepo, reportRepo, reactionRepo, bookmarkRepo, views, appLogger, userConn)
 	lis, err := net.Listen("tcp", cfg.Server.Port)
 	if err != nil {
 		appLogger.Fatal(err.Error())
@@ -86,10 +96,37 @@ func main() {
 	)
 
 	pb.RegisterArticlesServer(srv, h)
-	appLogger.Info("server starts at ", cfg.Server.Port)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	runWorker := func(run func(ctx context.Context)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			run(ctx)
+		}()
+	}
+	runWorker(worker.NewPublisher(cfg, repo, appLogger).Run)
+	runWorker(worker.NewTrashPurger(cfg, repo, appLogger).Run)
+	runWorker(worker.NewReconciler(cfg, repo, appLogger).Run)
+	runWorker(views.Run)
+
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+		appLogger.Info("shutting down server")
+		srv.GracefulStop()
+	}()
+
+	appLogger.Info("server starts at ", cfg.Server.Port)
 	if err := srv.Serve(lis); err != nil {
 		appLogger.Fatal(err.Error())
 	}
 
+	cancel()
+	wg.Wait()
+	appLogger.Info("server stopped")
 }
@@ -2,10 +2,13 @@ module github.com/rezaAmiri123/service-article
 
 go 1.14
 
+// the feed and the author profiles need the GetFollowings and GetProfiles rpcs of service-user,
+// replace the local checkout by the first service-user release providing them
 replace github.com/rezaAmiri123/service-user => ../service-user
 
 require (
 	github.com/go-ozzo/ozzo-validation v3.6.0+incompatible
+	github.com/go-sql-driver/mysql v1.5.0
 	github.com/gosimple/slug v1.9.0
 	github.com/grpc-ecosystem/go-grpc-middleware v1.0.0 // indirect
 	github.com/grpc-ecosystem/go-grpc-prometheus v1.2.0 // indirect
@@ -2,14 +2,21 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
 	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/rezaAmiri123/service-article/cmd/config"
 	pb "github.com/rezaAmiri123/service-article/gen/pb"
 	"github.com/rezaAmiri123/service-article/internal/model"
 	"github.com/rezaAmiri123/service-article/internal/repository"
@@ -17,14 +24,32 @@ import (
 	userPb "github.com/rezaAmiri123/service-user/gen/pb"
 )
 
+const defaultMaxCommentDepth = 5
+
 type articleHandler struct {
-	repo       repository.ArticleRepository
-	logger     logger.Logger
-	userClient userPb.UsersClient
+	cfg          *config.Config
+	repo         repository.ArticleRepository
+	tagRepo      repository.TagRepository
+	reportRepo   repository.ReportRepository
+	reactionRepo repository.ReactionRepository
+	bookmarkRepo repository.BookmarkRepository
+	views        ViewRecorder
+	logger       logger.Logger
+	userClient   userPb.UsersClient
 }
 
-func NewArticleHandler(repo repository.ArticleRepository, logger logger.Logger, userClient userPb.UsersClient) *articleHandler {
-	return &articleHandler{repo: repo, logger: logger, userClient: userClient}
+func NewArticleHandler(cfg *config.Config, repo repository.ArticleRepository, tagRepo repository.TagRepository, reportRepo repository.ReportRepository, reactionRepo repository.ReactionRepository, bookmarkRepo repository.BookmarkRepository, views ViewRecorder, logger logger.Logger, userClient userPb.UsersClient) *articleHandler {
+	return &articleHandler{
+		cfg:          cfg,
+		repo:         repo,
+		tagRepo:      tagRepo,
+		reportRepo:   reportRepo,
+		reactionRepo: reactionRepo,
+		bookmarkRepo: bookmarkRepo,
+		views:        views,
+		logger:       logger,
+		userClient:   userClient,
+	}
 }
 
 func (h *articleHandler) CreateArticle(ctx context.Context, req *pb.CreateArticleRequest) (*pb.Article, error) {
@@ -35,9 +60,19 @@ func (h *articleHandler) CreateArticle(ctx context.Context, req *pb.CreateArticl
 	if err != nil {
 		return nil, err
 	}
-	tags := make([]model.Tag, 0, len(req.GetTagList()))
-	for _, t := range req.GetTagList() {
-		tags = append(tags, model.Tag{Name: t})
+	tags, err := h.tagRepo.Resolve(ctx, req.GetTagList())
+	if err != nil {
+		msg := fmt.Sprintf("failed to get tags: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	articleStatus := model.StatusPublished
+	switch req.GetStatus() {
+	case pb.ArticleStatus_ARTICLE_STATUS_UNSPECIFIED, pb.ArticleStatus_ARTICLE_STATUS_PUBLISHED:
+	case pb.ArticleStatus_ARTICLE_STATUS_DRAFT:
+		articleStatus = model.StatusDraft
+	default:
+		msg := fmt.Sprintf("invalid initial status: %s", req.GetStatus())
+		return nil, status.Error(codes.InvalidArgument, msg)
 	}
 	article := model.Article{
 		Title:       req.GetTitle(),
@@ -46,6 +81,10 @@ func (h *articleHandler) CreateArticle(ctx context.Context, req *pb.CreateArticl
 		Body:        req.GetBody(),
 		UserID:      user.Id,
 		Tags:        tags,
+		Status:      articleStatus,
+	}
+	if req.GetPublishAt() != nil {
+		article.Schedule(req.GetPublishAt().AsTime())
 	}
 	if err = article.Validate(); err != nil {
 		return nil, err
@@ -53,7 +92,9 @@ func (h *articleHandler) CreateArticle(ctx context.Context, req *pb.CreateArticl
 	if err = h.repo.Create(ctx, &article); err != nil {
 		return nil, err
 	}
-	return article.ProtoArticle(true), nil
+	pa := article.ProtoArticle(true)
+	pa.Author = ownProfile(user)
+	return pa, nil
 }
 func (h *articleHandler) GetArticle(ctx context.Context, req *pb.GetArticleRequest) (*pb.Article, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.GetArticle")
@@ -65,7 +106,7 @@ func (h *articleHandler) GetArticle(ctx context.Context, req *pb.GetArticleReque
 		return nil, err
 	}
 
-	article, err := h.repo.GetBySlug(ctx, req.GetSlug())
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +116,25 @@ func (h *articleHandler) GetArticle(ctx context.Context, req *pb.GetArticleReque
 		msg := fmt.Sprintf("failded to get user favorited")
 		return nil, status.Error(codes.Aborted, msg)
 	}
-	return article.ProtoArticle(favorited), nil
+	pa := article.ProtoArticle(favorited)
+	if pa.Author, err = h.getProfile(ctx, article.UserID); err != nil {
+		return nil, err
+	}
+	if err = h.setArticleReactions(ctx, []uint{article.ID}, []*pb.Article{pa}, user.Id); err != nil {
+		return nil, err
+	}
+	if article.Slug != req.GetSlug() {
+		// requested by an old slug, let the gateway redirect to the canonical one
+		pa.CanonicalSlug = article.Slug
+		header := metadata.Pairs(
+			"x-http-code", "301",
+			"location", "/articles/"+article.Slug,
+		)
+		if err = grpc.SetHeader(ctx, header); err != nil {
+			h.logger.Warnf("failed to set redirect header: %v", err)
+		}
+	}
+	return pa, nil
 }
 
 func (h *articleHandler) GetArticles(ctx context.Context, req *pb.GetArticlesRequest) (*pb.ArticlesResponse, error) {
@@ -91,31 +150,185 @@ func (h *articleHandler) GetArticles(ctx context.Context, req *pb.GetArticlesReq
 	if limit == 0 {
 		limit = 20
 	}
-	as, err := h.repo.GetArticles(ctx, req.GetAuthorID(), req.GetTag(), req.GetFavorited(), limit, req.GetOffset())
+	page := repository.Page{Limit: limit, Offset: req.GetOffset(), Token: req.GetPageToken()}
+	order, ok := articleOrders[req.GetOrderBy()]
+	if !ok {
+		msg := fmt.Sprintf("invalid order: %s", req.GetOrderBy())
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	filter := articleFilter(req, user.Id)
+	as, nextPageToken, err := h.repo.GetArticles(ctx, filter, order, page)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		msg := fmt.Sprintf("failed to get articles: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	count, err := h.repo.CountArticles(ctx, filter)
 	if err != nil {
-		msg := fmt.Sprintf("failed to get articles: %w", err.Error())
+		msg := fmt.Sprintf("failed to count articles: %v", err)
 		return nil, status.Error(codes.Aborted, msg)
 	}
-	pas := make([]*pb.Article, len(as))
+	pas, err := h.protoArticles(ctx, as, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ArticlesResponse{
+		Articles:      pas,
+		ArticlesCount: int32(count),
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// protoArticles generates the proto articles of a listing with their favorited status
+// and reactions for the user and their authors, each resolved in a single lookup
+func (h *articleHandler) protoArticles(ctx context.Context, as []model.Article, userID string) ([]*pb.Article, error) {
+	ids := make([]uint, 0, len(as))
 	for _, a := range as {
-		favorited, err := h.repo.IsFavorited(ctx, &a, user.Id)
-		if err != nil {
-			msg := fmt.Sprintf("failed to get favorited status: %w", err.Error())
-			return nil, status.Error(codes.Aborted, msg)
-		}
-		pa := a.ProtoArticle(favorited)
-		pas = append(pas, pa)
+		ids = append(ids, a.ID)
+	}
+	favorited, err := h.repo.FavoritedSet(ctx, ids, userID)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get favorited status: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+
+	pas := make([]*pb.Article, 0, len(as))
+	for _, a := range as {
+		pas = append(pas, a.ProtoArticle(favorited[a.ID]))
+	}
+	if err = h.setArticleAuthors(ctx, as, pas); err != nil {
+		return nil, err
+	}
+	if err = h.setArticleReactions(ctx, ids, pas, userID); err != nil {
+		return nil, err
+	}
+	return pas, nil
+}
+
+var articleOrders = map[pb.ArticleOrder]repository.Order{
+	pb.ArticleOrder_ARTICLE_ORDER_NEWEST:           repository.OrderNewest,
+	pb.ArticleOrder_ARTICLE_ORDER_OLDEST:           repository.OrderOldest,
+	pb.ArticleOrder_ARTICLE_ORDER_MOST_FAVORITED:   repository.OrderMostFavorited,
+	pb.ArticleOrder_ARTICLE_ORDER_MOST_COMMENTED:   repository.OrderMostCommented,
+	pb.ArticleOrder_ARTICLE_ORDER_RECENTLY_UPDATED: repository.OrderRecentlyUpdated,
+	pb.ArticleOrder_ARTICLE_ORDER_MOST_VIEWED:      repository.OrderMostViewed,
+}
+
+// articleFilter creates the repository filter of the request
+func articleFilter(req *pb.GetArticlesRequest, viewerID string) repository.ArticleFilter {
+	filter := repository.ArticleFilter{
+		ViewerID:      viewerID,
+		AuthorIDs:     req.GetAuthorIDs(),
+		Tags:          req.GetTags(),
+		MatchAllTags:  req.GetMatch() == pb.TagMatch_TAG_MATCH_ALL,
+		ExcludeTags:   req.GetExcludeTags(),
+		FavoritedByID: req.GetFavorited(),
+	}
+	if req.GetAuthorID() != "" {
+		filter.AuthorIDs = append(filter.AuthorIDs, req.GetAuthorID())
+	}
+	if req.GetTag() != "" {
+		filter.Tags = append(filter.Tags, req.GetTag())
+	}
+	if req.GetCreatedAfter() != nil {
+		t := req.GetCreatedAfter().AsTime()
+		filter.CreatedAfter = &t
+	}
+	if req.GetCreatedBefore() != nil {
+		t := req.GetCreatedBefore().AsTime()
+		filter.CreatedBefore = &t
+	}
+	return filter
+}
+
+// getOwnArticle returns the current user and the article with the given slug,
+// it fails if the user is not the author of the article
+func (h *articleHandler) getOwnArticle(ctx context.Context, articleSlug string) (*userPb.UserResponse, *model.Article, error) {
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	article, err := h.repo.GetBySlug(ctx, articleSlug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if article.UserID != user.Id {
+		msg := fmt.Sprintf("wrong user")
+		return nil, nil, status.Error(codes.PermissionDenied, msg)
+	}
+	return user, article, nil
+}
+
+// getVisibleArticle returns the current user and the article with the given slug if the user can see it
+func (h *articleHandler) getVisibleArticle(ctx context.Context, articleSlug string) (*userPb.UserResponse, *model.Article, error) {
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
-	return &pb.ArticlesResponse{Articles: pas, ArticlesCount: int32(len(pas))}, nil
+
+	article, err := h.visibleArticle(ctx, articleSlug, user.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, article, nil
+}
+
+// visibleArticle returns the article with the given slug if the viewer can see it,
+// unpublished articles of other users are not found, viewerID is empty for anonymous viewers
+func (h *articleHandler) visibleArticle(ctx context.Context, articleSlug, viewerID string) (*model.Article, error) {
+	article, err := h.repo.GetBySlug(ctx, articleSlug)
+	if gorm.IsRecordNotFoundError(err) || (err == nil && !article.IsVisibleTo(viewerID)) {
+		msg := fmt.Sprintf("article not found")
+		return nil, status.Error(codes.NotFound, msg)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("failed to get article: %v", err)
+		return nil, status.Error(codes.Internal, msg)
+	}
+	return article, nil
 }
 
 func (h *articleHandler) getUser(ctx context.Context) (*userPb.UserResponse, error) {
 	empty := userPb.Empty{}
+	return h.userClient.GetUser(userContext(ctx), &empty)
+}
+
+// getOptionalUser returns the current user or nil for anonymous requests
+func (h *articleHandler) getOptionalUser(ctx context.Context) (*userPb.UserResponse, error) {
 	md, _ := metadata.FromIncomingContext(ctx)
-	newMD := metadata.New(map[string]string{"authorization": md.Get("authorization")[0]})
-	ctx = metadata.NewOutgoingContext(ctx, newMD)
+	if len(md.Get("authorization")) == 0 {
+		return nil, nil
+	}
+	return h.getUser(ctx)
+}
 
-	return h.userClient.GetUser(ctx, &empty)
+// getFollowings returns the ids of the users followed by the current user
+func (h *articleHandler) getFollowings(ctx context.Context) ([]string, error) {
+	empty := userPb.Empty{}
+	res, err := h.userClient.GetFollowings(userContext(ctx), &empty)
+	if status.Code(err) == codes.Unimplemented {
+		// the user service is older than the feed
+		msg := fmt.Sprintf("feed is not available, the user service does not support GetFollowings")
+		return nil, status.Error(codes.Unimplemented, msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.GetUserIDs(), nil
+}
+
+// userContext forwards the authorization of the incoming request to the user service
+func userContext(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	newMD := metadata.New(nil)
+	if vals := md.Get("authorization"); len(vals) > 0 {
+		newMD.Set("authorization", vals[0])
+	}
+	return metadata.NewOutgoingContext(ctx, newMD)
 }
 
 func (h *articleHandler) UpdateArticle(ctx context.Context, req *pb.UpdateArticleRequest) (*pb.Article, error) {
@@ -137,22 +350,143 @@ func (h *articleHandler) UpdateArticle(ctx context.Context, req *pb.UpdateArticl
 		return nil, status.Error(codes.PermissionDenied, msg)
 	}
 
-	article.Overwrite(
-		req.GetTitle(),
-		req.GetDescription(),
-		req.GetBody(),
-	)
+	if err = checkVersion(ctx, article, req.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	if len(req.GetUpdateMask().GetPaths()) > 0 {
+		if fields, err = h.applyUpdateMask(ctx, article, req); err != nil {
+			return nil, err
+		}
+	} else {
+		fields = article.Overwrite(
+			req.GetTitle(),
+			req.GetDescription(),
+			req.GetBody(),
+		)
+		if req.GetPublishAt() != nil {
+			if err = scheduleArticle(article, req.GetPublishAt()); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	if err = article.Validate(); err != nil {
 		err = fmt.Errorf("validation error: %w", err)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if err = h.repo.Update(ctx, article); err != nil {
-		msg := fmt.Sprintf("database error: %w", err.Error())
-		return nil, status.Error(codes.InvalidArgument, msg)
+	if err = h.repo.Update(ctx, article, user.Id, fields); err != nil {
+		return nil, updateError(article, err)
+	}
+	pa := article.ProtoArticle(true)
+	pa.Author = ownProfile(user)
+	return pa, nil
+}
+
+// applyUpdateMask sets the article fields listed in the update mask of the request,
+// it returns the changed fields
+func (h *articleHandler) applyUpdateMask(ctx context.Context, article *model.Article, req *pb.UpdateArticleRequest) ([]string, error) {
+	title, description, body := article.Title, article.Description, article.Body
+	tagsChanged := false
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "title":
+			title = req.GetTitle()
+		case "description":
+			description = req.GetDescription()
+		case "body":
+			body = req.GetBody()
+		// the gateway decodes the mask to snake case, grpc clients may send the field names
+		case "tagList", "tag_list":
+			tags, err := h.tagRepo.Resolve(ctx, req.GetTagList())
+			if err != nil {
+				msg := fmt.Sprintf("failed to get tags: %v", err)
+				return nil, status.Error(codes.Aborted, msg)
+			}
+			tagsChanged = article.SetTags(tags)
+		case "publishAt", "publish_at":
+			if err := scheduleArticle(article, req.GetPublishAt()); err != nil {
+				return nil, err
+			}
+		default:
+			msg := fmt.Sprintf("unknown update mask path: %s", path)
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+	}
+	fields := article.SetContent(title, description, body)
+	if tagsChanged {
+		fields = append(fields, model.FieldTags)
+	}
+	return fields, nil
+}
+
+// scheduleArticle sets the publish time of a draft or scheduled article,
+// a nil publish time removes the schedule
+func scheduleArticle(article *model.Article, publishAt *timestamppb.Timestamp) error {
+	if article.Status != model.StatusDraft && article.Status != model.StatusScheduled {
+		msg := fmt.Sprintf("only draft or scheduled articles can be scheduled")
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	if publishAt == nil {
+		article.Unschedule()
+		return nil
+	}
+	article.Schedule(publishAt.AsTime())
+	return nil
+}
+
+// checkVersion fails with a version conflict unless the article has the version the client expects,
+// taken from the request or from the If-Match header forwarded by the gateway,
+// the header matches when it is "*" or any of its entity tags is the current version,
+// weak entity tags never match since If-Match uses the strong comparison
+func checkVersion(ctx context.Context, article *model.Article, version int64) error {
+	if version != 0 {
+		if version != article.Version {
+			return versionConflictError(article)
+		}
+		return nil
 	}
-	return article.ProtoArticle(true), nil
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get("if-match")
+	if len(vals) == 0 {
+		return nil
+	}
+	for _, val := range vals {
+		for _, etag := range strings.Split(val, ",") {
+			etag = strings.TrimSpace(etag)
+			switch {
+			case etag == "":
+				continue
+			case etag == "*":
+				return nil
+			case strings.HasPrefix(etag, "W/"):
+				continue
+			case len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"':
+				msg := fmt.Sprintf("invalid If-Match header: %s", val)
+				return status.Error(codes.InvalidArgument, msg)
+			}
+			if v, err := strconv.ParseInt(etag[1:len(etag)-1], 10, 64); err == nil && v == article.Version {
+				return nil
+			}
+		}
+	}
+	return versionConflictError(article)
+}
+
+func versionConflictError(article *model.Article) error {
+	msg := fmt.Sprintf("article has been modified, current version is %d", article.Version)
+	return status.Error(codes.FailedPrecondition, msg)
+}
+
+// updateError converts an error of updating the article to a status error
+func updateError(article *model.Article, err error) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return versionConflictError(article)
+	}
+	msg := fmt.Sprintf("database error: %v", err)
+	return status.Error(codes.InvalidArgument, msg)
 }
 
 func (h *articleHandler) DeleteArticle(ctx context.Context, req *pb.DeleteArticleRequest) (*pb.Empty, error) {
@@ -175,13 +509,50 @@ func (h *articleHandler) DeleteArticle(ctx context.Context, req *pb.DeleteArticl
 	}
 
 	if err = h.repo.Delete(ctx, article); err != nil {
-		msg := fmt.Sprintf("database error: %w", err.Error())
+		msg := fmt.Sprintf("database error: %v", err)
 		return nil, status.Error(codes.InvalidArgument, msg)
 	}
 
 	return &pb.Empty{}, nil
 }
 
+func (h *articleHandler) PublishArticle(ctx context.Context, req *pb.PublishArticleRequest) (*pb.Article, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.PublishArticle")
+	defer span.Finish()
+
+	return h.setArticleStatus(ctx, req.GetSlug(), model.StatusPublished)
+}
+
+func (h *articleHandler) ArchiveArticle(ctx context.Context, req *pb.ArchiveArticleRequest) (*pb.Article, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.ArchiveArticle")
+	defer span.Finish()
+
+	return h.setArticleStatus(ctx, req.GetSlug(), model.StatusArchived)
+}
+
+func (h *articleHandler) setArticleStatus(ctx context.Context, articleSlug, articleStatus string) (*pb.Article, error) {
+	user, article, err := h.getOwnArticle(ctx, articleSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	if article.Status != articleStatus {
+		if err = h.repo.SetStatus(ctx, article, articleStatus); err != nil {
+			msg := fmt.Sprintf("database error: %v", err)
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+	}
+
+	favorited, err := h.repo.IsFavorited(ctx, article, user.Id)
+	if err != nil {
+		msg := fmt.Sprintf("failded to get user favorited")
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	pa := article.ProtoArticle(favorited)
+	pa.Author = ownProfile(user)
+	return pa, nil
+}
+
 func (h *articleHandler) CreateComment(ctx context.Context, req *pb.CreateCommentRequest) (*pb.Comment, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.CreateComment")
 	defer span.Finish()
@@ -191,7 +562,7 @@ func (h *articleHandler) CreateComment(ctx context.Context, req *pb.CreateCommen
 		return nil, err
 	}
 
-	article, err := h.repo.GetBySlug(ctx, req.GetSlug())
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -200,13 +571,73 @@ func (h *articleHandler) CreateComment(ctx context.Context, req *pb.CreateCommen
 		ArticleID: article.ID,
 		UserID:    user.Id,
 	}
+	if req.GetParentId() != "" {
+		parent, err := h.getComment(ctx, article, req.GetParentId(), user.Id)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Depth >= h.maxCommentDepth() {
+			msg := fmt.Sprintf("replies can not be nested deeper than %d levels", h.maxCommentDepth())
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+		if parent.Tombstoned {
+			msg := fmt.Sprintf("can not reply to a deleted comment")
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+		comment.ReplyTo(parent)
+	}
 	if err := comment.Validate(); err != nil {
 		return nil, err
 	}
 	if err := h.repo.CreateComment(ctx, &comment); err != nil {
 		return nil, err
 	}
-	return comment.ProtoComment(), nil
+	pc := comment.ProtoComment()
+	pc.Author = ownProfile(user)
+	return pc, nil
+}
+
+func (h *articleHandler) UpdateComment(ctx context.Context, req *pb.UpdateCommentRequest) (*pb.Comment, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.UpdateComment")
+	defer span.Finish()
+
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.Id)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := h.getComment(ctx, article, req.GetId(), user.Id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.UserID != user.Id {
+		msg := fmt.Sprintf("wrong user")
+		return nil, status.Error(codes.PermissionDenied, msg)
+	}
+	if comment.Tombstoned {
+		msg := fmt.Sprintf("comment not found")
+		return nil, status.Error(codes.NotFound, msg)
+	}
+
+	if req.GetBody() != comment.Body {
+		edited := *comment
+		edited.Body = req.GetBody()
+		if err = edited.Validate(); err != nil {
+			err = fmt.Errorf("validation error: %w", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err = h.repo.UpdateComment(ctx, comment, req.GetBody()); err != nil {
+			msg := fmt.Sprintf("database error: %v", err)
+			return nil, status.Error(codes.InvalidArgument, msg)
+		}
+	}
+	pc := comment.ProtoComment()
+	pc.Author = ownProfile(user)
+	return pc, nil
 }
 
 func (h *articleHandler) DeleteComment(ctx context.Context, req *pb.DeleteCommentRequest) (*pb.Empty, error) {
@@ -218,54 +649,169 @@ func (h *articleHandler) DeleteComment(ctx context.Context, req *pb.DeleteCommen
 		return nil, err
 	}
 
-	article, err := h.repo.GetBySlug(ctx, req.GetSlug())
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.Id)
 	if err != nil {
 		return nil, err
 	}
 
-	comment, err := h.repo.GetCommentByID(ctx, req.GetId())
+	comment, err := h.getComment(ctx, article, req.GetId(), user.Id)
 	if err != nil {
-		msg := fmt.Sprintf("database error: %w", err.Error())
-		return nil, status.Error(codes.InvalidArgument, msg)
+		return nil, err
 	}
-	if article.UserID != user.Id || comment.ArticleID != article.ID {
+	// comments can be deleted by their author, the author of the article and moderators
+	if comment.UserID != user.Id && article.UserID != user.Id && !h.isModerator(user.Id) {
 		msg := fmt.Sprintf("wrong user")
 		return nil, status.Error(codes.PermissionDenied, msg)
 	}
-	err = h.repo.DeleteComment(ctx, comment)
-	if err != nil {
-		msg := fmt.Sprintf("database error: %w", err.Error())
-		return nil, status.Error(codes.InvalidArgument, msg)
+	if err = h.deleteComment(ctx, comment, h.isModerator(user.Id)); err != nil {
+		return nil, err
 	}
 	return &pb.Empty{}, nil
 }
 
+// deleteComment deletes the comment or keeps a placeholder for its replies, the replies of
+// other users are only deleted along with the comment by moderators when tombstones are disabled
+func (h *articleHandler) deleteComment(ctx context.Context, comment *model.Comment, moderator bool) error {
+	var err error
+	if comment.RepliesCount > 0 && (h.cfg.Comment.Tombstones || !moderator) {
+		err = h.repo.TombstoneComment(ctx, comment)
+	} else {
+		err = h.repo.DeleteComment(ctx, comment)
+	}
+	if err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
 func (h *articleHandler) GetComments(ctx context.Context, req *pb.GetCommentsRequest) (*pb.CommentsResponse, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.GetComments")
 	defer span.Finish()
 
-	//user, err := h.getUser(ctx)
-	//if err != nil {
-	//	return nil, err
-	//}
+	user, err := h.getOptionalUser(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	article, err := h.repo.GetBySlug(ctx, req.GetSlug())
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.GetId())
 	if err != nil {
-		msg := fmt.Sprintf("database error: %w", err.Error())
+		return nil, err
+	}
+
+	filter := h.commentFilter(user)
+	filter.TopLevelOnly = req.GetTopLevelOnly()
+	page := repository.Page{Limit: req.GetLimit(), Token: req.GetPageToken()}
+	comments, nextPageToken, err := h.repo.GetComments(ctx, article, filter, page)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
 		return nil, status.Error(codes.InvalidArgument, msg)
 	}
 
-	comments, err := h.repo.GetComments(ctx, article)
+	pcs, err := h.protoComments(ctx, comments, user.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CommentsResponse{Comments: pcs, NextPageToken: nextPageToken}, nil
+}
+
+func (h *articleHandler) GetCommentReplies(ctx context.Context, req *pb.GetCommentRepliesRequest) (*pb.CommentsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.GetCommentReplies")
+	defer span.Finish()
+
+	user, err := h.getOptionalUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.GetId())
+	if err != nil {
+		return nil, err
+	}
+	comment, err := h.getComment(ctx, article, req.GetId(), user.GetId())
 	if err != nil {
-		msg := fmt.Sprintf("database error: %w", err.Error())
+		return nil, err
+	}
+
+	page := repository.Page{Limit: req.GetLimit(), Token: req.GetPageToken()}
+	replies, nextPageToken, err := h.repo.GetReplies(ctx, comment, h.commentFilter(user), page)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
 		return nil, status.Error(codes.InvalidArgument, msg)
 	}
 
+	pcs, err := h.protoComments(ctx, replies, user.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CommentsResponse{Comments: pcs, NextPageToken: nextPageToken}, nil
+}
+
+// protoComments generates the proto comments of a listing with their authors
+// and their reactions for the user
+func (h *articleHandler) protoComments(ctx context.Context, comments []model.Comment, userID string) ([]*pb.Comment, error) {
+	ids := make([]uint, 0, len(comments))
 	pcs := make([]*pb.Comment, 0, len(comments))
 	for _, c := range comments {
+		ids = append(ids, c.ID)
 		pcs = append(pcs, c.ProtoComment())
 	}
-	return &pb.CommentsResponse{Comments: pcs}, nil
+	if err := h.setCommentAuthors(ctx, comments, pcs); err != nil {
+		return nil, err
+	}
+	if err := h.setCommentReactions(ctx, ids, pcs, userID); err != nil {
+		return nil, err
+	}
+	return pcs, nil
+}
+
+// commentFilter shows hidden comments to moderators and to their author
+func (h *articleHandler) commentFilter(user *userPb.UserResponse) repository.CommentFilter {
+	if user == nil {
+		return repository.CommentFilter{}
+	}
+	return repository.CommentFilter{ViewerID: user.Id, ShowHidden: h.isModerator(user.Id)}
+}
+
+// getComment returns the comment with the given id of the article, hidden comments
+// are only found by their author and moderators
+func (h *articleHandler) getComment(ctx context.Context, article *model.Article, id, viewerID string) (*model.Comment, error) {
+	comment, err := h.repo.GetCommentByID(ctx, id)
+	if err != nil || comment.ArticleID != article.ID ||
+		(comment.Hidden && comment.UserID != viewerID && !h.isModerator(viewerID)) {
+		msg := fmt.Sprintf("comment not found")
+		return nil, status.Error(codes.NotFound, msg)
+	}
+	return comment, nil
+}
+
+// isModerator reports whether the user can moderate comments, admins are moderators as well
+func (h *articleHandler) isModerator(userID string) bool {
+	for _, id := range h.cfg.Roles.Moderators {
+		if id == userID {
+			return true
+		}
+	}
+	for _, id := range h.cfg.Roles.Admins {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// maxCommentDepth returns the deepest nesting level of replies
+func (h *articleHandler) maxCommentDepth() int32 {
+	if h.cfg.Comment.MaxDepth <= 0 {
+		return defaultMaxCommentDepth
+	}
+	return h.cfg.Comment.MaxDepth
 }
 
 func (h *articleHandler) FavoriteArticle(ctx context.Context, req *pb.FavoriteArticleRequest) (*pb.Article, error) {
@@ -277,16 +823,20 @@ func (h *articleHandler) FavoriteArticle(ctx context.Context, req *pb.FavoriteAr
 		return nil, err
 	}
 
-	article, err := h.repo.GetBySlug(ctx, req.GetSlug())
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.Id)
 	if err != nil {
 		return nil, err
 	}
 	err = h.repo.AddFavorite(ctx, article, user.Id)
 	if err != nil {
-		msg := fmt.Sprintf("failed to add favorite: %w", err.Error())
-		return nil, status.Error(codes.InvalidArgument, msg)
+		msg := fmt.Sprintf("failed to add favorite: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
 	}
-	return article.ProtoArticle(true), nil
+	pa := article.ProtoArticle(true)
+	if pa.Author, err = h.getProfile(ctx, article.UserID); err != nil {
+		return nil, err
+	}
+	return pa, nil
 }
 
 func (h *articleHandler) UnfavoriteArticle(ctx context.Context, req *pb.FavoriteArticleRequest) (*pb.Article, error) {
@@ -297,14 +847,18 @@ func (h *articleHandler) UnfavoriteArticle(ctx context.Context, req *pb.Favorite
 		return nil, err
 	}
 
-	article, err := h.repo.GetBySlug(ctx, req.GetSlug())
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.Id)
 	if err != nil {
 		return nil, err
 	}
 	err = h.repo.DeleteFavorite(ctx, article, user.Id)
 	if err != nil {
-		msg := fmt.Sprintf("failed to delete favorite: %w", err.Error())
-		return nil, status.Error(codes.InvalidArgument, msg)
+		msg := fmt.Sprintf("failed to delete favorite: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	pa := article.ProtoArticle(false)
+	if pa.Author, err = h.getProfile(ctx, article.UserID); err != nil {
+		return nil, err
 	}
-	return article.ProtoArticle(false), nil
+	return pa, nil
 }
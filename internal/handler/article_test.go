@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/rezaAmiri123/service-article/cmd/config"
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+)
+
+// fakeTagRepository resolves the tags in memory
+type fakeTagRepository struct {
+	repository.TagRepository
+}
+
+func (r fakeTagRepository) Resolve(ctx context.Context, names []string) ([]model.Tag, error) {
+	tags := make([]model.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, model.Tag{Name: name})
+	}
+	return tags, nil
+}
+
+func TestApplyUpdateMaskFromJSON(t *testing.T) {
+	// the request body as it is decoded by the gateway
+	body := `{
+		"title": "New title",
+		"description": "",
+		"tagList": ["go", "grpc"],
+		"publishAt": "2030-01-02T03:04:05Z",
+		"updateMask": "title,description,tagList,publishAt"
+	}`
+	req := &pb.UpdateArticleRequest{}
+	if err := protojson.Unmarshal([]byte(body), req); err != nil {
+		t.Fatalf("unmarshal request: %v", err)
+	}
+
+	h := &articleHandler{tagRepo: fakeTagRepository{}}
+	article := &model.Article{
+		Title:       "Old title",
+		Description: "old description",
+		Body:        "body",
+		Status:      model.StatusDraft,
+	}
+	fields, err := h.applyUpdateMask(context.Background(), article, req)
+	if err != nil {
+		t.Fatalf("applyUpdateMask(%v): %v", req.GetUpdateMask().GetPaths(), err)
+	}
+
+	want := []string{model.FieldTitle, model.FieldDescription, model.FieldTags}
+	if len(fields) != len(want) {
+		t.Fatalf("fields = %v, want %v", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Fatalf("fields = %v, want %v", fields, want)
+		}
+	}
+	if article.Title != "New title" || article.Description != "" || article.Body != "body" {
+		t.Errorf("content = %q, %q, %q", article.Title, article.Description, article.Body)
+	}
+	if len(article.Tags) != 2 || article.Tags[0].Name != "go" || article.Tags[1].Name != "grpc" {
+		t.Errorf("tags = %v, want [go grpc]", article.Tags)
+	}
+	publishAt := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	if article.PublishAt == nil || !article.PublishAt.Equal(publishAt) || article.Status != model.StatusScheduled {
+		t.Errorf("publishAt = %v, status = %s, want %v, %s", article.PublishAt, article.Status, publishAt, model.StatusScheduled)
+	}
+}
+
+func TestApplyUpdateMaskFieldNames(t *testing.T) {
+	h := &articleHandler{tagRepo: fakeTagRepository{}}
+	for _, path := range []string{"tagList", "tag_list"} {
+		req := &pb.UpdateArticleRequest{TagList: []string{"go"}}
+		req.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{path}}
+		article := &model.Article{Status: model.StatusDraft}
+		fields, err := h.applyUpdateMask(context.Background(), article, req)
+		if err != nil {
+			t.Fatalf("path %s: %v", path, err)
+		}
+		if len(fields) != 1 || fields[0] != model.FieldTags {
+			t.Errorf("path %s: fields = %v, want [%s]", path, fields, model.FieldTags)
+		}
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	tests := []struct {
+		ifMatch string
+		version int64
+		want    codes.Code
+	}{
+		{ifMatch: "", want: codes.OK},
+		{ifMatch: `"3"`, want: codes.OK},
+		{ifMatch: `"4"`, want: codes.FailedPrecondition},
+		{ifMatch: `W/"3"`, want: codes.FailedPrecondition},
+		{ifMatch: "*", want: codes.OK},
+		{ifMatch: `"4", "3"`, want: codes.OK},
+		{ifMatch: `W/"3", "4"`, want: codes.FailedPrecondition},
+		{ifMatch: ` W/"3" ,"3"`, want: codes.OK},
+		{ifMatch: `"abc"`, want: codes.FailedPrecondition},
+		{ifMatch: `3`, want: codes.InvalidArgument},
+		// the version of the request wins over the header
+		{ifMatch: `"4"`, version: 3, want: codes.OK},
+		{ifMatch: `"3"`, version: 4, want: codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.ifMatch != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("if-match", tt.ifMatch))
+		}
+		article := &model.Article{Version: 3}
+		err := checkVersion(ctx, article, tt.version)
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("checkVersion(%q, %d) error = %v, want code %s", tt.ifMatch, tt.version, err, tt.want)
+		}
+	}
+}
+
+// fakeArticleRepository serves a single article by its slug and a single comment by its id
+type fakeArticleRepository struct {
+	repository.ArticleRepository
+	article *model.Article
+	comment *model.Comment
+	err     error
+}
+
+func (r fakeArticleRepository) GetBySlug(ctx context.Context, slug string) (*model.Article, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.article == nil || r.article.Slug != slug {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.article, nil
+}
+
+func TestVisibleArticle(t *testing.T) {
+	draft := &model.Article{Slug: "draft", UserID: "author", Status: model.StatusDraft}
+	published := &model.Article{Slug: "published", UserID: "author", Status: model.StatusPublished}
+	tests := []struct {
+		name     string
+		repo     fakeArticleRepository
+		slug     string
+		viewerID string
+		want     codes.Code
+	}{
+		{name: "published", repo: fakeArticleRepository{article: published}, slug: "published", want: codes.OK},
+		{name: "own draft", repo: fakeArticleRepository{article: draft}, slug: "draft", viewerID: "author", want: codes.OK},
+		{name: "draft of another user", repo: fakeArticleRepository{article: draft}, slug: "draft", viewerID: "reader", want: codes.NotFound},
+		{name: "anonymous draft", repo: fakeArticleRepository{article: draft}, slug: "draft", want: codes.NotFound},
+		{name: "missing", repo: fakeArticleRepository{article: published}, slug: "missing", want: codes.NotFound},
+		{name: "database error", repo: fakeArticleRepository{err: errors.New("connection refused")}, slug: "published", want: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &articleHandler{repo: tt.repo}
+			_, err := h.visibleArticle(context.Background(), tt.slug, tt.viewerID)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("visibleArticle error = %v, want code %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func (r fakeArticleRepository) GetCommentByID(ctx context.Context, id string) (*model.Comment, error) {
+	if r.comment == nil || fmt.Sprintf("%d", r.comment.ID) != id {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.comment, nil
+}
+
+func TestGetHiddenComment(t *testing.T) {
+	article := &model.Article{Model: gorm.Model{ID: 1}}
+	comment := &model.Comment{Model: gorm.Model{ID: 2}, ArticleID: 1, UserID: "author", Hidden: true}
+	h := &articleHandler{
+		cfg:  &config.Config{Roles: config.RolesConfig{Moderators: []string{"moderator"}}},
+		repo: fakeArticleRepository{comment: comment},
+	}
+	for viewerID, want := range map[string]codes.Code{
+		"author":    codes.OK,
+		"moderator": codes.OK,
+		"reader":    codes.NotFound,
+		"":          codes.NotFound,
+	} {
+		_, err := h.getComment(context.Background(), article, "2", viewerID)
+		if got := status.Code(err); got != want {
+			t.Errorf("getComment for viewer %q error = %v, want code %s", viewerID, err, want)
+		}
+	}
+}
+
+// fakeCommentRepository records how comments are deleted
+type fakeCommentRepository struct {
+	repository.ArticleRepository
+	deleted string
+}
+
+func (r *fakeCommentRepository) DeleteComment(ctx context.Context, comment *model.Comment) error {
+	r.deleted = "thread"
+	return nil
+}
+
+func (r *fakeCommentRepository) TombstoneComment(ctx context.Context, comment *model.Comment) error {
+	r.deleted = "tombstone"
+	return nil
+}
+
+func TestDeleteComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		tombstones bool
+		replies    int32
+		moderator  bool
+		want       string
+	}{
+		{name: "without replies", replies: 0, want: "thread"},
+		{name: "with replies", replies: 2, want: "tombstone"},
+		{name: "with replies by a moderator", replies: 2, moderator: true, want: "thread"},
+		{name: "with replies and tombstones", tombstones: true, replies: 2, want: "tombstone"},
+		{name: "with replies and tombstones by a moderator", tombstones: true, replies: 2, moderator: true, want: "tombstone"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeCommentRepository{}
+			h := &articleHandler{
+				cfg:  &config.Config{Comment: config.CommentConfig{Tombstones: tt.tombstones}},
+				repo: repo,
+			}
+			comment := &model.Comment{RepliesCount: tt.replies}
+			if err := h.deleteComment(context.Background(), comment, tt.moderator); err != nil {
+				t.Fatalf("deleteComment: %v", err)
+			}
+			if repo.deleted != tt.want {
+				t.Errorf("deleted = %s, want %s", repo.deleted, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+)
+
+func (h *articleHandler) BookmarkArticle(ctx context.Context, req *pb.BookmarkArticleRequest) (*pb.Bookmark, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.BookmarkArticle")
+	defer span.Finish()
+
+	user, article, err := h.getVisibleArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	bookmark := model.NewBookmark(user.Id, article.ID, req.GetFolder())
+	if err = bookmark.Validate(); err != nil {
+		err = fmt.Errorf("validation error: %w", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = h.bookmarkRepo.Add(ctx, &bookmark); err != nil {
+		msg := fmt.Sprintf("failed to add bookmark: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+
+	pas, err := h.protoArticles(ctx, []model.Article{*article}, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	return bookmark.ProtoBookmark(pas[0]), nil
+}
+
+func (h *articleHandler) RemoveBookmark(ctx context.Context, req *pb.RemoveBookmarkRequest) (*pb.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.RemoveBookmark")
+	defer span.Finish()
+
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	article, err := h.repo.GetBySlug(ctx, req.GetSlug())
+	if err != nil {
+		msg := fmt.Sprintf("article not found")
+		return nil, status.Error(codes.NotFound, msg)
+	}
+
+	if err = h.bookmarkRepo.Remove(ctx, user.Id, article.ID); err != nil {
+		msg := fmt.Sprintf("failed to remove bookmark: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *articleHandler) ListBookmarks(ctx context.Context, req *pb.ListBookmarksRequest) (*pb.BookmarksResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.ListBookmarks")
+	defer span.Finish()
+
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = 20
+	}
+	page := repository.Page{Limit: limit, Token: req.GetPageToken()}
+	bookmarks, nextPageToken, err := h.bookmarkRepo.List(ctx, user.Id, strings.TrimSpace(req.GetFolder()), page)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		msg := fmt.Sprintf("failed to get bookmarks: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+
+	as := make([]model.Article, 0, len(bookmarks))
+	for _, b := range bookmarks {
+		as = append(as, b.Article)
+	}
+	pas, err := h.protoArticles(ctx, as, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	pbs := make([]*pb.Bookmark, 0, len(bookmarks))
+	for i, b := range bookmarks {
+		pbs = append(pbs, b.ProtoBookmark(pas[i]))
+	}
+	return &pb.BookmarksResponse{Bookmarks: pbs, NextPageToken: nextPageToken}, nil
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	"github.com/rezaAmiri123/service-article/internal/testdb"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+// fakeTokenUsersClient answers GetUser with the user whose id is the authorization token
+type fakeTokenUsersClient struct {
+	fakeProfilesClient
+}
+
+func (c fakeTokenUsersClient) GetUser(ctx context.Context, in *userPb.Empty, opts ...grpc.CallOption) (*userPb.UserResponse, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return &userPb.UserResponse{Id: md.Get("authorization")[0]}, nil
+}
+
+func TestConcurrentFavoriteArticle(t *testing.T) {
+	db := testdb.Open(t)
+	repo := repository.NewORMArticleRepository(db)
+	h := &articleHandler{repo: repo, userClient: fakeTokenUsersClient{}}
+	article := &model.Article{
+		Title:       "Favorite",
+		Slug:        "favorite",
+		Description: "description",
+		Body:        "body",
+		UserID:      "author",
+		Status:      model.StatusPublished,
+	}
+	if err := repo.Create(context.Background(), article); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// the users favorite the article several times at once, then unfavorite it the same way
+	const users, n = 8, 32
+	for _, tt := range []struct {
+		favorite  bool
+		wantCount int32
+	}{
+		{favorite: true, wantCount: users},
+		{favorite: false, wantCount: 0},
+	} {
+		var wg sync.WaitGroup
+		errs := make(chan error, n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(u int) {
+				defer wg.Done()
+				md := metadata.Pairs("authorization", fmt.Sprintf("user-%d", u))
+				ctx := metadata.NewIncomingContext(context.Background(), md)
+				req := &pb.FavoriteArticleRequest{Slug: article.Slug}
+				var err error
+				if tt.favorite {
+					_, err = h.FavoriteArticle(ctx, req)
+				} else {
+					_, err = h.UnfavoriteArticle(ctx, req)
+				}
+				errs <- err
+			}(i % users)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Errorf("favorite %t: %v", tt.favorite, err)
+			}
+		}
+
+		var got model.Article
+		if err := db.First(&got, article.ID).Error; err != nil {
+			t.Fatalf("get article: %v", err)
+		}
+		if got.FavoritesCount != tt.wantCount {
+			t.Errorf("favorite %t: favorites_count = %d, want %d", tt.favorite, got.FavoritesCount, tt.wantCount)
+		}
+	}
+}
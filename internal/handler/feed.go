@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+)
+
+func (h *articleHandler) FeedArticles(ctx context.Context, req *pb.FeedArticlesRequest) (*pb.ArticlesResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.FeedArticles")
+	defer span.Finish()
+
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	followings, err := h.getFollowings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(followings) == 0 {
+		return &pb.ArticlesResponse{Articles: []*pb.Article{}}, nil
+	}
+
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = 20
+	}
+	page := repository.Page{Limit: limit, Offset: req.GetOffset(), Token: req.GetPageToken()}
+	filter := repository.ArticleFilter{ViewerID: user.Id, AuthorIDs: followings}
+	as, nextPageToken, err := h.repo.GetArticles(ctx, filter, repository.OrderNewest, page)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		msg := fmt.Sprintf("failed to get articles: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	count, err := h.repo.CountArticles(ctx, filter)
+	if err != nil {
+		msg := fmt.Sprintf("failed to count articles: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+
+	pas, err := h.protoArticles(ctx, as, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ArticlesResponse{
+		Articles:      pas,
+		ArticlesCount: int32(count),
+		NextPageToken: nextPageToken,
+	}, nil
+}
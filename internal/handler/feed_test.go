@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+// fakeUsersClient answers as a user service without the rpcs it doesn't override
+type fakeUsersClient struct {
+	userPb.UsersClient
+	user *userPb.UserResponse
+}
+
+func (c fakeUsersClient) GetUser(ctx context.Context, in *userPb.Empty, opts ...grpc.CallOption) (*userPb.UserResponse, error) {
+	return c.user, nil
+}
+
+func (c fakeUsersClient) GetFollowings(ctx context.Context, in *userPb.Empty, opts ...grpc.CallOption) (*userPb.FollowingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method GetFollowings")
+}
+
+func (c fakeUsersClient) GetProfiles(ctx context.Context, in *userPb.ProfilesRequest, opts ...grpc.CallOption) (*userPb.ProfilesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "unknown method GetProfiles")
+}
+
+func TestFeedWithoutGetFollowings(t *testing.T) {
+	h := &articleHandler{userClient: fakeUsersClient{user: &userPb.UserResponse{Id: "42"}}}
+	_, err := h.FeedArticles(context.Background(), &pb.FeedArticlesRequest{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("FeedArticles error = %v, want code %s", err, codes.Unimplemented)
+	}
+}
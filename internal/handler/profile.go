@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+// getProfiles resolves the profiles of the users in a single call to the user service,
+// following is relative to the current user
+func (h *articleHandler) getProfiles(ctx context.Context, userIDs []string) (map[string]*pb.Profile, error) {
+	profiles := make(map[string]*pb.Profile, len(userIDs))
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := profiles[id]; ok {
+			continue
+		}
+		profiles[id] = nil
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return profiles, nil
+	}
+
+	res, err := h.userClient.GetProfiles(userContext(ctx), &userPb.ProfilesRequest{UserIDs: ids})
+	if err != nil {
+		msg := fmt.Sprintf("failed to get profiles: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	for _, p := range res.GetProfiles() {
+		profiles[p.GetId()] = &pb.Profile{
+			Username:  p.GetUsername(),
+			Bio:       p.GetBio(),
+			Image:     p.GetImage(),
+			Following: p.GetFollowing(),
+		}
+	}
+	return profiles, nil
+}
+
+// getProfile resolves the profile of a single user
+func (h *articleHandler) getProfile(ctx context.Context, userID string) (*pb.Profile, error) {
+	profiles, err := h.getProfiles(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	return profiles[userID], nil
+}
+
+// ownProfile returns the profile of the current user without asking the user service again
+func ownProfile(user *userPb.UserResponse) *pb.Profile {
+	return &pb.Profile{
+		Username: user.GetUsername(),
+		Bio:      user.GetBio(),
+		Image:    user.GetImage(),
+	}
+}
+
+// setArticleAuthors sets the author profiles of the proto articles generated from the articles
+func (h *articleHandler) setArticleAuthors(ctx context.Context, as []model.Article, pas []*pb.Article) error {
+	ids := make([]string, 0, len(as))
+	for _, a := range as {
+		ids = append(ids, a.UserID)
+	}
+	profiles, err := h.getProfiles(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i, a := range as {
+		pas[i].Author = profiles[a.UserID]
+	}
+	return nil
+}
+
+// setCommentAuthors sets the author profiles of the proto comments generated from the comments
+func (h *articleHandler) setCommentAuthors(ctx context.Context, comments []model.Comment, pcs []*pb.Comment) error {
+	ids := make([]string, 0, len(comments))
+	for _, c := range comments {
+		ids = append(ids, c.UserID)
+	}
+	profiles, err := h.getProfiles(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for i, c := range comments {
+		// placeholders of deleted comments don't reveal their author
+		if !c.Tombstoned {
+			pcs[i].Author = profiles[c.UserID]
+		}
+	}
+	return nil
+}
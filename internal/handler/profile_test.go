@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+// fakeProfilesClient answers GetProfiles with the profiles of the users it knows
+type fakeProfilesClient struct {
+	fakeUsersClient
+	profiles map[string]*userPb.ProfileResponse
+}
+
+func (c fakeProfilesClient) GetProfiles(ctx context.Context, in *userPb.ProfilesRequest, opts ...grpc.CallOption) (*userPb.ProfilesResponse, error) {
+	res := &userPb.ProfilesResponse{}
+	for _, id := range in.UserIDs {
+		if p, ok := c.profiles[id]; ok {
+			res.Profiles = append(res.Profiles, p)
+		}
+	}
+	return res, nil
+}
+
+func TestArticleAuthors(t *testing.T) {
+	h := &articleHandler{userClient: fakeProfilesClient{
+		fakeUsersClient: fakeUsersClient{user: &userPb.UserResponse{Id: "42"}},
+		profiles: map[string]*userPb.ProfileResponse{
+			"1": {Id: "1", Username: "alice", Following: true},
+			"2": {Id: "2", Username: "bob"},
+		},
+	}}
+
+	as := []model.Article{{UserID: "1"}, {UserID: "2"}, {UserID: "1"}}
+	pas := []*pb.Article{{}, {}, {}}
+	if err := h.setArticleAuthors(context.Background(), as, pas); err != nil {
+		t.Fatalf("setArticleAuthors: %v", err)
+	}
+	want := []string{"alice", "bob", "alice"}
+	for i, pa := range pas {
+		if pa.GetAuthor().GetUsername() != want[i] {
+			t.Errorf("author of article %d = %v, want %s", i, pa.GetAuthor(), want[i])
+		}
+	}
+	if !pas[0].GetAuthor().GetFollowing() || pas[1].GetAuthor().GetFollowing() {
+		t.Errorf("following = %t, %t, want true, false", pas[0].GetAuthor().GetFollowing(), pas[1].GetAuthor().GetFollowing())
+	}
+}
+
+func TestAuthorsWithoutGetProfiles(t *testing.T) {
+	h := &articleHandler{userClient: fakeUsersClient{user: &userPb.UserResponse{Id: "42"}}}
+
+	as := []model.Article{{UserID: "1"}, {UserID: "2"}}
+	pas := []*pb.Article{{}, {}}
+	err := h.setArticleAuthors(context.Background(), as, pas)
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("setArticleAuthors error = %v, want code %s", err, codes.Aborted)
+	}
+}
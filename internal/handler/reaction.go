@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+// defaultReactions are used when no reactions are configured
+var defaultReactions = []string{"like", "insightful", "funny"}
+
+func (h *articleHandler) AddReaction(ctx context.Context, req *pb.ReactionRequest) (*pb.Reactions, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.AddReaction")
+	defer span.Finish()
+
+	// reactions removed from the configuration can still be taken back
+	if !h.isReaction(req.GetReaction()) {
+		msg := fmt.Sprintf("unknown reaction: %s", req.GetReaction())
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	reaction, err := h.getReaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err = h.reactionRepo.Add(ctx, reaction); err != nil {
+		msg := fmt.Sprintf("failed to add reaction: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	return h.targetReactions(ctx, reaction)
+}
+
+func (h *articleHandler) RemoveReaction(ctx context.Context, req *pb.ReactionRequest) (*pb.Reactions, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.RemoveReaction")
+	defer span.Finish()
+
+	reaction, err := h.getReaction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err = h.reactionRepo.Remove(ctx, reaction); err != nil {
+		msg := fmt.Sprintf("failed to remove reaction: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	return h.targetReactions(ctx, reaction)
+}
+
+// getReaction returns the reaction of the current user to the article or comment of the request
+func (h *articleHandler) getReaction(ctx context.Context, req *pb.ReactionRequest) (*model.Reaction, error) {
+	user, article, err := h.getVisibleArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+	reaction := model.Reaction{
+		UserID:    user.Id,
+		ArticleID: article.ID,
+		Name:      req.GetReaction(),
+	}
+	if req.GetCommentId() != "" {
+		comment, err := h.getComment(ctx, article, req.GetCommentId(), user.Id)
+		if err != nil {
+			return nil, err
+		}
+		if comment.Tombstoned {
+			msg := fmt.Sprintf("comment not found")
+			return nil, status.Error(codes.NotFound, msg)
+		}
+		reaction.CommentID = comment.ID
+	}
+	return &reaction, nil
+}
+
+// targetReactions returns the reactions to the article or comment the reaction is on
+func (h *articleHandler) targetReactions(ctx context.Context, reaction *model.Reaction) (*pb.Reactions, error) {
+	if reaction.CommentID != 0 {
+		pc := &pb.Comment{}
+		err := h.setCommentReactions(ctx, []uint{reaction.CommentID}, []*pb.Comment{pc}, reaction.UserID)
+		return pc.Reactions, err
+	}
+	pa := &pb.Article{}
+	err := h.setArticleReactions(ctx, []uint{reaction.ArticleID}, []*pb.Article{pa}, reaction.UserID)
+	return pa.Reactions, err
+}
+
+// setArticleReactions sets the reactions of the proto articles of the article ids,
+// along with the reactions of the user
+func (h *articleHandler) setArticleReactions(ctx context.Context, ids []uint, pas []*pb.Article, userID string) error {
+	counts, err := h.reactionRepo.ArticleCounts(ctx, ids)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get reactions: %v", err)
+		return status.Error(codes.Aborted, msg)
+	}
+	reacted, err := h.reactionRepo.ArticleReactions(ctx, ids, userID)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get reactions: %v", err)
+		return status.Error(codes.Aborted, msg)
+	}
+	for i, id := range ids {
+		pas[i].Reactions = model.ProtoReactions(counts[id], reacted[id])
+	}
+	return nil
+}
+
+// setCommentReactions sets the reactions of the proto comments of the comment ids,
+// along with the reactions of the user
+func (h *articleHandler) setCommentReactions(ctx context.Context, ids []uint, pcs []*pb.Comment, userID string) error {
+	counts, err := h.reactionRepo.CommentCounts(ctx, ids)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get reactions: %v", err)
+		return status.Error(codes.Aborted, msg)
+	}
+	reacted, err := h.reactionRepo.CommentReactions(ctx, ids, userID)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get reactions: %v", err)
+		return status.Error(codes.Aborted, msg)
+	}
+	for i, id := range ids {
+		pcs[i].Reactions = model.ProtoReactions(counts[id], reacted[id])
+	}
+	return nil
+}
+
+// isReaction reports whether the name is one of the configured reactions
+func (h *articleHandler) isReaction(name string) bool {
+	names := h.cfg.Reactions.Names
+	if len(names) == 0 {
+		names = defaultReactions
+	}
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
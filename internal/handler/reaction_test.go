@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rezaAmiri123/service-article/cmd/config"
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+// fakeReactionRepository keeps the reactions of a single user in memory
+type fakeReactionRepository struct {
+	repository.ReactionRepository
+	reactions map[string]bool
+}
+
+func (r fakeReactionRepository) Add(ctx context.Context, reaction *model.Reaction) error {
+	r.reactions[reaction.Name] = true
+	return nil
+}
+
+func (r fakeReactionRepository) Remove(ctx context.Context, reaction *model.Reaction) error {
+	delete(r.reactions, reaction.Name)
+	return nil
+}
+
+func (r fakeReactionRepository) ArticleCounts(ctx context.Context, articleIDs []uint) (map[uint]map[string]int32, error) {
+	return map[uint]map[string]int32{}, nil
+}
+
+func (r fakeReactionRepository) ArticleReactions(ctx context.Context, articleIDs []uint, userID string) (map[uint][]string, error) {
+	return map[uint][]string{}, nil
+}
+
+func TestReactionNames(t *testing.T) {
+	article := &model.Article{Slug: "article", UserID: "author", Status: model.StatusPublished}
+	reactions := fakeReactionRepository{reactions: map[string]bool{"retired": true}}
+	h := &articleHandler{
+		cfg:          &config.Config{Reactions: config.ReactionsConfig{Names: []string{"like"}}},
+		repo:         fakeArticleRepository{article: article},
+		reactionRepo: reactions,
+		userClient:   fakeUsersClient{user: &userPb.UserResponse{Id: "42"}},
+	}
+
+	_, err := h.AddReaction(context.Background(), &pb.ReactionRequest{Slug: "article", Reaction: "retired"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("AddReaction(retired) error = %v, want code %s", err, codes.InvalidArgument)
+	}
+	if _, err = h.AddReaction(context.Background(), &pb.ReactionRequest{Slug: "article", Reaction: "like"}); err != nil {
+		t.Errorf("AddReaction(like): %v", err)
+	}
+	// a reaction which is no longer configured can still be removed
+	if _, err = h.RemoveReaction(context.Background(), &pb.ReactionRequest{Slug: "article", Reaction: "retired"}); err != nil {
+		t.Errorf("RemoveReaction(retired): %v", err)
+	}
+	if reactions.reactions["retired"] || !reactions.reactions["like"] {
+		t.Errorf("reactions = %v, want like", reactions.reactions)
+	}
+}
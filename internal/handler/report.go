@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+func (h *articleHandler) ReportComment(ctx context.Context, req *pb.ReportCommentRequest) (*pb.Report, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.ReportComment")
+	defer span.Finish()
+
+	user, article, err := h.getVisibleArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+	comment, err := h.getComment(ctx, article, req.GetId(), user.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	report := model.Report{
+		UserID:    user.Id,
+		ArticleID: article.ID,
+		Article:   *article,
+		CommentID: &comment.ID,
+		Reason:    req.GetReason(),
+	}
+	return h.createReport(ctx, &report)
+}
+
+func (h *articleHandler) ReportArticle(ctx context.Context, req *pb.ReportArticleRequest) (*pb.Report, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.ReportArticle")
+	defer span.Finish()
+
+	user, article, err := h.getVisibleArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	report := model.Report{
+		UserID:    user.Id,
+		ArticleID: article.ID,
+		Article:   *article,
+		Reason:    req.GetReason(),
+	}
+	return h.createReport(ctx, &report)
+}
+
+func (h *articleHandler) createReport(ctx context.Context, report *model.Report) (*pb.Report, error) {
+	if err := report.Validate(); err != nil {
+		err = fmt.Errorf("validation error: %w", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := h.reportRepo.Create(ctx, report); err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	return report.ProtoReport(), nil
+}
+
+func (h *articleHandler) ListReports(ctx context.Context, req *pb.ListReportsRequest) (*pb.ReportsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.ListReports")
+	defer span.Finish()
+
+	if _, err := h.getModerator(ctx); err != nil {
+		return nil, err
+	}
+
+	reportStatus := model.ReportOpen
+	if req.GetStatus() != pb.ReportStatus_REPORT_STATUS_UNSPECIFIED {
+		reportStatus = model.ReportStatusFromProto(req.GetStatus())
+	}
+	if reportStatus == "" {
+		msg := fmt.Sprintf("invalid status: %s", req.GetStatus())
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = 20
+	}
+	page := repository.Page{Limit: limit, Token: req.GetPageToken()}
+	reports, nextPageToken, err := h.reportRepo.List(ctx, reportStatus, page)
+	if errors.Is(err, repository.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		msg := fmt.Sprintf("failed to get reports: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+
+	prs := make([]*pb.Report, 0, len(reports))
+	for _, r := range reports {
+		prs = append(prs, r.ProtoReport())
+	}
+	return &pb.ReportsResponse{Reports: prs, NextPageToken: nextPageToken}, nil
+}
+
+func (h *articleHandler) ResolveReport(ctx context.Context, req *pb.ResolveReportRequest) (*pb.Report, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.ResolveReport")
+	defer span.Finish()
+
+	moderator, err := h.getModerator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := h.reportRepo.GetByID(ctx, req.GetId())
+	if err != nil {
+		msg := fmt.Sprintf("report not found")
+		return nil, status.Error(codes.NotFound, msg)
+	}
+
+	var reportStatus string
+	switch req.GetAction() {
+	case pb.ReportAction_REPORT_ACTION_DISMISS:
+		reportStatus = model.ReportDismissed
+	case pb.ReportAction_REPORT_ACTION_HIDE:
+		reportStatus = model.ReportHidden
+		err = h.hideReported(ctx, report)
+	case pb.ReportAction_REPORT_ACTION_DELETE:
+		reportStatus = model.ReportDeleted
+		err = h.deleteReported(ctx, report)
+	default:
+		msg := fmt.Sprintf("invalid action: %s", req.GetAction())
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.reportRepo.Resolve(ctx, report, reportStatus, moderator.Id); err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	return report.ProtoReport(), nil
+}
+
+// hideReported hides the reported comment
+func (h *articleHandler) hideReported(ctx context.Context, report *model.Report) error {
+	if report.CommentID == nil {
+		msg := fmt.Sprintf("articles can not be hidden")
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	comment, err := h.repo.GetCommentByID(ctx, fmt.Sprintf("%d", *report.CommentID))
+	if err != nil {
+		// the comment has already been deleted
+		return nil
+	}
+	if err = h.repo.HideComment(ctx, comment); err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// deleteReported deletes the reported comment or article
+func (h *articleHandler) deleteReported(ctx context.Context, report *model.Report) error {
+	if report.CommentID != nil {
+		comment, err := h.repo.GetCommentByID(ctx, fmt.Sprintf("%d", *report.CommentID))
+		if err != nil {
+			// the comment has already been deleted
+			return nil
+		}
+		// reports are resolved by moderators
+		return h.deleteComment(ctx, comment, true)
+	}
+	if report.Article.DeletedAt != nil {
+		return nil
+	}
+	if err := h.repo.Delete(ctx, &report.Article); err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
+		return status.Error(codes.InvalidArgument, msg)
+	}
+	return nil
+}
+
+// getModerator returns the current user if it is a moderator
+func (h *articleHandler) getModerator(ctx context.Context) (*userPb.UserResponse, error) {
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !h.isModerator(user.Id) {
+		msg := fmt.Sprintf("moderator permission required")
+		return nil, status.Error(codes.PermissionDenied, msg)
+	}
+	return user, nil
+}
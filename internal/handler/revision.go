@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/pkg/diff"
+)
+
+func (h *articleHandler) ListRevisions(ctx context.Context, req *pb.ListRevisionsRequest) (*pb.RevisionsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.ListRevisions")
+	defer span.Finish()
+
+	_, article, err := h.getOwnArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = 20
+	}
+	rs, err := h.repo.ListRevisions(ctx, article, limit, req.GetOffset())
+	if err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	prs := make([]*pb.Revision, 0, len(rs))
+	for _, r := range rs {
+		prs = append(prs, r.ProtoRevision())
+	}
+	return &pb.RevisionsResponse{Revisions: prs}, nil
+}
+
+func (h *articleHandler) GetRevision(ctx context.Context, req *pb.GetRevisionRequest) (*pb.Revision, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.GetRevision")
+	defer span.Finish()
+
+	_, article, err := h.getOwnArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := h.getRevision(ctx, article, req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	return r.ProtoRevision(), nil
+}
+
+func (h *articleHandler) DiffRevisions(ctx context.Context, req *pb.DiffRevisionsRequest) (*pb.RevisionDiff, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.DiffRevisions")
+	defer span.Finish()
+
+	_, article, err := h.getOwnArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := h.getRevision(ctx, article, req.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	to, err := h.getRevision(ctx, article, req.GetTo())
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, 4)
+	if from.Title != to.Title {
+		fields = append(fields, model.FieldTitle)
+	}
+	if from.Description != to.Description {
+		fields = append(fields, model.FieldDescription)
+	}
+	if from.Body != to.Body {
+		fields = append(fields, model.FieldBody)
+	}
+	fromTags, fromOK := from.TagList()
+	toTags, toOK := to.TagList()
+	if fromOK && toOK && !sameTags(fromTags, toTags) {
+		fields = append(fields, model.FieldTags)
+	}
+	body, err := diff.Unified(
+		fmt.Sprintf("revision %d", from.Number),
+		fmt.Sprintf("revision %d", to.Number),
+		from.Body,
+		to.Body,
+	)
+	if err != nil {
+		msg := fmt.Sprintf("failed to diff revisions %d and %d: %v", from.Number, to.Number, err)
+		return nil, status.Error(codes.ResourceExhausted, msg)
+	}
+	return &pb.RevisionDiff{
+		From:   from.Number,
+		To:     to.Number,
+		Fields: fields,
+		Body:   body,
+	}, nil
+}
+
+func (h *articleHandler) RestoreRevision(ctx context.Context, req *pb.RestoreRevisionRequest) (*pb.Article, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.RestoreRevision")
+	defer span.Finish()
+
+	user, article, err := h.getOwnArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkVersion(ctx, article, req.GetExpectedVersion()); err != nil {
+		return nil, err
+	}
+	r, err := h.getRevision(ctx, article, req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+
+	fields := article.SetContent(r.Title, r.Description, r.Body)
+	// revisions stored before tags were kept leave the current tags
+	if names, ok := r.TagList(); ok {
+		tags, err := h.tagRepo.Resolve(ctx, names)
+		if err != nil {
+			msg := fmt.Sprintf("failed to get tags: %v", err)
+			return nil, status.Error(codes.Aborted, msg)
+		}
+		if article.SetTags(tags) {
+			fields = append(fields, model.FieldTags)
+		}
+	}
+	if err = article.Validate(); err != nil {
+		err = fmt.Errorf("validation error: %w", err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = h.repo.Update(ctx, article, user.Id, fields); err != nil {
+		return nil, updateError(article, err)
+	}
+
+	favorited, err := h.repo.IsFavorited(ctx, article, user.Id)
+	if err != nil {
+		msg := fmt.Sprintf("failded to get user favorited")
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	pa := article.ProtoArticle(favorited)
+	pa.Author = ownProfile(user)
+	return pa, nil
+}
+
+func (h *articleHandler) getRevision(ctx context.Context, article *model.Article, number int32) (*model.ArticleRevision, error) {
+	r, err := h.repo.GetRevision(ctx, article, number)
+	if err != nil {
+		msg := fmt.Sprintf("revision %d not found", number)
+		return nil, status.Error(codes.NotFound, msg)
+	}
+	return r, nil
+}
+
+// sameTags reports whether both lists have the same tag names in any order
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	names := make(map[string]bool, len(a))
+	for _, n := range a {
+		names[n] = true
+	}
+	for _, n := range b {
+		if !names[n] {
+			return false
+		}
+	}
+	return true
+}
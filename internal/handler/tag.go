@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+func (h *articleHandler) GetTags(ctx context.Context, req *pb.GetTagsRequest) (*pb.TagsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.GetTags")
+	defer span.Finish()
+
+	tcs, err := h.tagRepo.GetTags(ctx)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get tags: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+
+	pts := make([]*pb.Tag, 0, len(tcs))
+	for _, t := range tcs {
+		pts = append(pts, t.ProtoTag())
+	}
+	return &pb.TagsResponse{Tags: pts}, nil
+}
+
+func (h *articleHandler) RenameTag(ctx context.Context, req *pb.RenameTagRequest) (*pb.Tag, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.RenameTag")
+	defer span.Finish()
+
+	if _, err := h.getAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if model.NormalizeTagName(req.GetNewName()) == "" {
+		msg := fmt.Sprintf("new tag name is required")
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	tag, err := h.getTag(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err = h.tagRepo.Rename(ctx, tag, req.GetNewName()); err != nil {
+		return nil, tagError(err)
+	}
+	return h.protoTag(ctx, tag)
+}
+
+func (h *articleHandler) MergeTags(ctx context.Context, req *pb.MergeTagsRequest) (*pb.Tag, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.MergeTags")
+	defer span.Finish()
+
+	if _, err := h.getAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	source, err := h.getTag(ctx, req.GetSource())
+	if err != nil {
+		return nil, err
+	}
+	target, err := h.getTag(ctx, req.GetTarget())
+	if err != nil {
+		return nil, err
+	}
+	if source.ID == target.ID {
+		msg := fmt.Sprintf("cannot merge a tag into itself")
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	if err = h.tagRepo.Merge(ctx, source, target); err != nil {
+		return nil, tagError(err)
+	}
+	return h.protoTag(ctx, target)
+}
+
+func (h *articleHandler) AddTagAlias(ctx context.Context, req *pb.AddTagAliasRequest) (*pb.Tag, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.AddTagAlias")
+	defer span.Finish()
+
+	if _, err := h.getAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if model.NormalizeTagName(req.GetAlias()) == "" {
+		msg := fmt.Sprintf("alias is required")
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	tag, err := h.getTag(ctx, req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	if err = h.tagRepo.AddAlias(ctx, tag, req.GetAlias()); err != nil {
+		return nil, tagError(err)
+	}
+	return h.protoTag(ctx, tag)
+}
+
+// getAdmin returns the current user if it is an admin
+func (h *articleHandler) getAdmin(ctx context.Context) (*userPb.UserResponse, error) {
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range h.cfg.Roles.Admins {
+		if id == user.Id {
+			return user, nil
+		}
+	}
+	msg := fmt.Sprintf("admin permission required")
+	return nil, status.Error(codes.PermissionDenied, msg)
+}
+
+func (h *articleHandler) getTag(ctx context.Context, name string) (*model.Tag, error) {
+	tag, err := h.tagRepo.GetByName(ctx, name)
+	if err != nil {
+		msg := fmt.Sprintf("tag %s not found", name)
+		return nil, status.Error(codes.NotFound, msg)
+	}
+	return tag, nil
+}
+
+func (h *articleHandler) protoTag(ctx context.Context, tag *model.Tag) (*pb.Tag, error) {
+	tc, err := h.tagRepo.Count(ctx, tag)
+	if err != nil {
+		msg := fmt.Sprintf("failed to get tag: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	return tc.ProtoTag(), nil
+}
+
+// tagError converts an error of changing a tag to a status error
+func tagError(err error) error {
+	if errors.Is(err, repository.ErrTagNameTaken) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+	msg := fmt.Sprintf("database error: %v", err)
+	return status.Error(codes.InvalidArgument, msg)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	"github.com/rezaAmiri123/service-article/internal/model"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+func (h *articleHandler) ListDeletedArticles(ctx context.Context, req *pb.ListDeletedArticlesRequest) (*pb.ArticlesResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.ListDeletedArticles")
+	defer span.Finish()
+
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.GetLimit()
+	if limit == 0 {
+		limit = 20
+	}
+	as, err := h.repo.ListDeleted(ctx, user.Id, limit, req.GetOffset())
+	if err != nil {
+		msg := fmt.Sprintf("failed to get deleted articles: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	count, err := h.repo.CountDeleted(ctx, user.Id)
+	if err != nil {
+		msg := fmt.Sprintf("failed to count deleted articles: %v", err)
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	author := ownProfile(user)
+	pas := make([]*pb.Article, 0, len(as))
+	for _, a := range as {
+		pa := a.ProtoArticle(false)
+		pa.Author = author
+		pas = append(pas, pa)
+	}
+	return &pb.ArticlesResponse{Articles: pas, ArticlesCount: int32(count)}, nil
+}
+
+func (h *articleHandler) RestoreArticle(ctx context.Context, req *pb.RestoreArticleRequest) (*pb.Article, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.RestoreArticle")
+	defer span.Finish()
+
+	user, article, err := h.getOwnDeletedArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.repo.Restore(ctx, article); err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+
+	favorited, err := h.repo.IsFavorited(ctx, article, user.Id)
+	if err != nil {
+		msg := fmt.Sprintf("failded to get user favorited")
+		return nil, status.Error(codes.Aborted, msg)
+	}
+	pa := article.ProtoArticle(favorited)
+	pa.Author = ownProfile(user)
+	return pa, nil
+}
+
+func (h *articleHandler) PurgeArticle(ctx context.Context, req *pb.PurgeArticleRequest) (*pb.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.PurgeArticle")
+	defer span.Finish()
+
+	_, article, err := h.getOwnDeletedArticle(ctx, req.GetSlug())
+	if err != nil {
+		return nil, err
+	}
+
+	if err = h.repo.Purge(ctx, article); err != nil {
+		msg := fmt.Sprintf("database error: %v", err)
+		return nil, status.Error(codes.InvalidArgument, msg)
+	}
+	return &pb.Empty{}, nil
+}
+
+// getOwnDeletedArticle returns the current user and their deleted article with the given slug
+func (h *articleHandler) getOwnDeletedArticle(ctx context.Context, articleSlug string) (*userPb.UserResponse, *model.Article, error) {
+	user, err := h.getUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	article, err := h.repo.GetDeletedBySlug(ctx, articleSlug)
+	if err != nil {
+		msg := fmt.Sprintf("deleted article not found")
+		return nil, nil, status.Error(codes.NotFound, msg)
+	}
+
+	if article.UserID != user.Id {
+		msg := fmt.Sprintf("wrong user")
+		return nil, nil, status.Error(codes.PermissionDenied, msg)
+	}
+	return user, article, nil
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+// maximum length of the fingerprints of anonymous viewers
+const maxFingerprintLength = 128
+
+// ViewRecorder counts article views, deduplicated per viewer,
+// views of viewers who can't be identified are not counted
+type ViewRecorder interface {
+	Record(articleID uint, viewer string) bool
+}
+
+func (h *articleHandler) RecordView(ctx context.Context, req *pb.RecordViewRequest) (*pb.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "articleHandler.RecordView")
+	defer span.Finish()
+
+	user, err := h.getOptionalUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	article, err := h.visibleArticle(ctx, req.GetSlug(), user.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	h.views.Record(article.ID, viewerKey(ctx, user, req.GetFingerprint()))
+	return &pb.Empty{}, nil
+}
+
+// viewerKey identifies the viewer by their user id, by the fingerprint of anonymous viewers,
+// by the client address and user agent forwarded by the gateway or by the address of a grpc client,
+// it is empty when the viewer can't be identified
+func viewerKey(ctx context.Context, user *userPb.UserResponse, fingerprint string) string {
+	if user != nil {
+		return "user:" + user.Id
+	}
+	if fingerprint != "" {
+		if len(fingerprint) > maxFingerprintLength {
+			fingerprint = fingerprint[:maxFingerprintLength]
+		}
+		return "fp:" + fingerprint
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	addr := forwardedAddr(md)
+	if addr == "" {
+		return peerKey(ctx)
+	}
+	key := "addr:" + addr
+	if agents := md.Get("grpcgateway-user-agent"); len(agents) > 0 {
+		key += "|" + agents[0]
+	}
+	return key
+}
+
+// forwardedAddr returns the client address the gateway appended to x-forwarded-for,
+// the hops before it are sent by the client and can't be trusted
+func forwardedAddr(md metadata.MD) string {
+	vals := md.Get("x-forwarded-for")
+	if len(vals) == 0 {
+		return ""
+	}
+	hops := strings.Split(vals[len(vals)-1], ",")
+	return strings.TrimSpace(hops[len(hops)-1])
+}
+
+// peerKey identifies a grpc client calling directly by its host, it is empty without a peer address
+func peerKey(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	if host == "" {
+		return ""
+	}
+	return "addr:" + host
+}
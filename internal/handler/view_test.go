@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	userPb "github.com/rezaAmiri123/service-user/gen/pb"
+)
+
+func TestViewerKey(t *testing.T) {
+	gateway := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-forwarded-for", "203.0.113.7",
+		"grpcgateway-user-agent", "curl/7.68.0",
+	))
+	spoofed := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"x-forwarded-for", "192.0.2.1, 203.0.113.7",
+		"grpcgateway-user-agent", "curl/7.68.0",
+	))
+	direct := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("198.51.100.2"), Port: 52344},
+	})
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		user        *userPb.UserResponse
+		fingerprint string
+		want        string
+	}{
+		{name: "user", ctx: gateway, user: &userPb.UserResponse{Id: "42"}, fingerprint: "abc", want: "user:42"},
+		{name: "fingerprint", ctx: gateway, fingerprint: "abc", want: "fp:abc"},
+		{name: "gateway client", ctx: gateway, want: "addr:203.0.113.7|curl/7.68.0"},
+		{name: "gateway client with a forwarded for header", ctx: spoofed, want: "addr:203.0.113.7|curl/7.68.0"},
+		{name: "grpc client", ctx: direct, want: "addr:198.51.100.2"},
+		{name: "unidentified", ctx: context.Background(), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := viewerKey(tt.ctx, tt.user, tt.fingerprint); got != tt.want {
+				t.Errorf("viewerKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
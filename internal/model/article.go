@@ -1,25 +1,41 @@
 package model
 
 import (
+	"time"
+
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/gosimple/slug"
 	"github.com/jinzhu/gorm"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/rezaAmiri123/service-article/gen/pb"
 )
 
+// Article statuses
+const (
+	StatusDraft     = "draft"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+	StatusScheduled = "scheduled"
+)
+
 // Article model
 type Article struct {
 	gorm.Model
 	Title          string `gorm:"not null"`
-	Slug           string `gorm:"not null"`
+	Slug           string `gorm:"not null;unique_index"`
 	Description    string `gorm:"not null"`
 	Body           string `gorm:"not null"`
 	Tags           []Tag  `gorm:"many2many:article_tags"`
 	UserID         string `gorm:"not null"`
 	Comments       []Comment
 	Favorited      []FavoriteArticle
-	FavoritesCount int32 `gorm:"not null;default=0"`
+	FavoritesCount int32  `gorm:"not null;default=0"`
+	Status         string `gorm:"not null;default:'published'"`
+	PublishAt      *time.Time
+	Version        int64 `gorm:"not null;default:1"`
+	CommentsCount  int32 `gorm:"not null;default:0"`
+	ViewsCount     int64 `gorm:"not null;default:0"`
 }
 
 // Validate validates fields of article model
@@ -28,21 +44,98 @@ func (a Article) Validate() error {
 		validation.Field(&a.Title, validation.Required),
 		validation.Field(&a.Body, validation.Required),
 		validation.Field(&a.Tags, validation.Required),
+		validation.Field(&a.Status, validation.In(StatusDraft, StatusPublished, StatusArchived, StatusScheduled)),
 	)
 }
 
-// Overwrite overwrite each field if it's not zero-value
-func (a *Article) Overwrite(title, description, body string) {
-	if title != "" {
+// IsVisibleTo reports whether the article can be read by the given user,
+// drafts and scheduled articles are only visible to their author
+func (a *Article) IsVisibleTo(userID string) bool {
+	if a.UserID == userID {
+		return true
+	}
+	return a.Status != StatusDraft && a.Status != StatusScheduled
+}
+
+// Unschedule removes the publish time of the article,
+// a scheduled article goes back to draft
+func (a *Article) Unschedule() {
+	a.PublishAt = nil
+	if a.Status == StatusScheduled {
+		a.Status = StatusDraft
+	}
+}
+
+// Schedule sets the publish time of the article, an article which is due
+// is published right away
+func (a *Article) Schedule(publishAt time.Time) {
+	a.PublishAt = &publishAt
+	if publishAt.After(time.Now()) {
+		a.Status = StatusScheduled
+	} else {
+		a.Status = StatusPublished
+	}
+}
+
+// Article content fields tracked by revisions
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldBody        = "body"
+	FieldTags        = "tags"
+)
+
+// Overwrite overwrite each field if it's not zero-value,
+// it returns the fields which have been changed
+func (a *Article) Overwrite(title, description, body string) []string {
+	if title == "" {
+		title = a.Title
+	}
+	if description == "" {
+		description = a.Description
+	}
+	if body == "" {
+		body = a.Body
+	}
+	return a.SetContent(title, description, body)
+}
+
+// SetContent sets title, description and body of the article,
+// it returns the fields which have been changed
+func (a *Article) SetContent(title, description, body string) []string {
+	fields := make([]string, 0, 3)
+	if title != a.Title {
 		a.Title = title
 		a.Slug = slug.Make(title)
+		fields = append(fields, FieldTitle)
 	}
-	if description != "" {
+	if description != a.Description {
 		a.Description = description
+		fields = append(fields, FieldDescription)
 	}
-	if body != "" {
+	if body != a.Body {
 		a.Body = body
+		fields = append(fields, FieldBody)
+	}
+	return fields
+}
+
+// SetTags replaces the tags of the article, it reports whether they have been changed
+func (a *Article) SetTags(tags []Tag) bool {
+	current := make(map[string]bool, len(a.Tags))
+	for _, t := range a.Tags {
+		current[t.Name] = true
+	}
+	changed := len(tags) != len(current)
+	for _, t := range tags {
+		if !current[t.Name] {
+			changed = true
+		}
+	}
+	if changed {
+		a.Tags = tags
 	}
+	return changed
 }
 
 // ProtoArticle generates proto article model from article
@@ -54,6 +147,15 @@ func (a *Article) ProtoArticle(favorited bool) *pb.Article {
 		Body:           a.Body,
 		Favorited:      favorited,
 		FavoritesCount: a.FavoritesCount,
+		Status:         ProtoStatus(a.Status),
+		Version:        a.Version,
+		CommentsCount:  a.CommentsCount,
+		ViewsCount:     a.ViewsCount,
+		CreatedAt:      timestamppb.New(a.CreatedAt),
+		UpdatedAt:      timestamppb.New(a.UpdatedAt),
+	}
+	if a.PublishAt != nil {
+		pa.PublishAt = timestamppb.New(*a.PublishAt)
 	}
 
 	// article tags
@@ -64,3 +166,26 @@ func (a *Article) ProtoArticle(favorited bool) *pb.Article {
 	pa.TagList = tags
 	return &pa
 }
+
+var protoStatuses = map[string]pb.ArticleStatus{
+	StatusDraft:     pb.ArticleStatus_ARTICLE_STATUS_DRAFT,
+	StatusPublished: pb.ArticleStatus_ARTICLE_STATUS_PUBLISHED,
+	StatusArchived:  pb.ArticleStatus_ARTICLE_STATUS_ARCHIVED,
+	StatusScheduled: pb.ArticleStatus_ARTICLE_STATUS_SCHEDULED,
+}
+
+// ProtoStatus converts article status to proto article status
+func ProtoStatus(status string) pb.ArticleStatus {
+	return protoStatuses[status]
+}
+
+// StatusFromProto converts proto article status to article status,
+// it returns an empty string for unspecified status
+func StatusFromProto(status pb.ArticleStatus) string {
+	for s, ps := range protoStatuses {
+		if ps == status {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+)
+
+// Bookmark model is an article saved to the private reading list of a user
+type Bookmark struct {
+	ID        uint `gorm:"primary_key"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    string `gorm:"not null;unique_index:idx_bookmarks_user_article"`
+	ArticleID uint   `gorm:"not null;unique_index:idx_bookmarks_user_article"`
+	Article   Article
+	// Folder is empty for bookmarks out of any folder
+	Folder string `gorm:"not null;default:'';size:64"`
+}
+
+// NewBookmark creates a bookmark of the article for the user in the folder
+func NewBookmark(userID string, articleID uint, folder string) Bookmark {
+	return Bookmark{UserID: userID, ArticleID: articleID, Folder: strings.TrimSpace(folder)}
+}
+
+// Validate validates fields of bookmark model
+func (b Bookmark) Validate() error {
+	return validation.ValidateStruct(&b,
+		validation.Field(&b.Folder, validation.Length(0, 64)),
+	)
+}
+
+// ProtoBookmark generates proto bookmark model from bookmark and its proto article
+func (b *Bookmark) ProtoBookmark(article *pb.Article) *pb.Bookmark {
+	return &pb.Bookmark{
+		Article:   article,
+		Folder:    b.Folder,
+		CreatedAt: timestamppb.New(b.CreatedAt),
+	}
+}
@@ -2,20 +2,41 @@ package model
 
 import (
 	"fmt"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/jinzhu/gorm"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pb "github.com/rezaAmiri123/service-article/gen/pb"
 )
 
+// DeletedCommentBody replaces the body of tombstoned comments
+const DeletedCommentBody = "[deleted]"
+
 // Comment model
 type Comment struct {
 	gorm.Model
 	Body      string `gorm:"not null"`
-	UserID    string   `gorm:"not null"`
+	UserID    string `gorm:"not null"`
 	ArticleID uint   `gorm:"not null"`
 	Article   Article
+	// ParentID is the comment this one replies to, nil for top level comments
+	ParentID     *uint
+	Depth        int32 `gorm:"not null;default:0"`
+	RepliesCount int32 `gorm:"not null;default:0"`
+	EditedAt     *time.Time
+	// Tombstoned comments were deleted but are kept as placeholders for their replies
+	Tombstoned bool `gorm:"not null;default:false"`
+	// Hidden comments were hidden by a moderator
+	Hidden bool `gorm:"not null;default:false"`
+}
+
+// CommentRevision model keeps a previous body of an edited comment
+type CommentRevision struct {
+	gorm.Model
+	CommentID uint   `gorm:"not null;index"`
+	Body      string `gorm:"not null"`
 }
 
 // Validate validates fields of comment model
@@ -27,8 +48,31 @@ func (c Comment) Validate() error {
 
 // ProtoComment generates proto comment model from article
 func (c *Comment) ProtoComment() *pb.Comment {
-	return &pb.Comment{
-		Id:   fmt.Sprintf("%d", c.ID),
-		Body: c.Body,
+	pc := &pb.Comment{
+		Id:           fmt.Sprintf("%d", c.ID),
+		Body:         c.Body,
+		CreatedAt:    timestamppb.New(c.CreatedAt),
+		UpdatedAt:    timestamppb.New(c.UpdatedAt),
+		Depth:        c.Depth,
+		RepliesCount: c.RepliesCount,
 	}
+	if c.Tombstoned {
+		pc.Body = DeletedCommentBody
+		pc.Deleted = true
+	}
+	pc.Hidden = c.Hidden
+	if c.EditedAt != nil {
+		pc.Edited = true
+		pc.EditedAt = timestamppb.New(*c.EditedAt)
+	}
+	if c.ParentID != nil {
+		pc.ParentId = fmt.Sprintf("%d", *c.ParentID)
+	}
+	return pc
+}
+
+// ReplyTo makes the comment a reply to the parent comment
+func (c *Comment) ReplyTo(parent *Comment) {
+	c.ParentID = &parent.ID
+	c.Depth = parent.Depth + 1
 }
@@ -2,10 +2,10 @@ package model
 
 import "github.com/jinzhu/gorm"
 
-// Comment model
+// FavoriteArticle model, favorites are deleted for good so a user favorites an article at most once
 type FavoriteArticle struct {
 	gorm.Model
-	UserID    string   `gorm:"not null"`
-	ArticleID uint   `gorm:"not null"`
+	UserID    string `gorm:"not null;unique_index:idx_favorite_articles_user_article"`
+	ArticleID uint   `gorm:"not null;unique_index:idx_favorite_articles_user_article"`
 	Article   Article
 }
@@ -1,12 +1,170 @@
 package model
 
-import "github.com/jinzhu/gorm"
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+)
 
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := dedupeSlugs(db); err != nil {
+		return err
+	}
+	if err := dedupeTags(db); err != nil {
+		return err
+	}
+	backfillFavoritesCount, err := dedupeFavorites(db)
+	if err != nil {
+		return err
+	}
+	backfillCommentsCount := db.HasTable(&Article{}) && !db.Dialect().HasColumn("articles", "comments_count")
+	err = db.AutoMigrate(
 		&FavoriteArticle{},
 		&Tag{},
 		&Comment{},
 		&Article{},
+		&ArticleRevision{},
+		&SlugAlias{},
+		&TagAlias{},
+		&CommentRevision{},
+		&Report{},
+		&Reaction{},
+		&ReactionCount{},
+		&Bookmark{},
 	).Error
+	if err != nil {
+		return err
+	}
+	if err = addListingIndexes(db); err != nil {
+		return err
+	}
+	if backfillCommentsCount {
+		err = db.Exec("UPDATE articles SET comments_count = " +
+			"(SELECT COUNT(*) FROM comments WHERE comments.article_id = articles.id AND comments.deleted_at IS NULL)").Error
+		if err != nil {
+			return err
+		}
+	}
+	if backfillFavoritesCount {
+		return db.Exec("UPDATE articles SET favorites_count = " +
+			"(SELECT COUNT(*) FROM favorite_articles WHERE favorite_articles.article_id = articles.id)").Error
+	}
+	return nil
+}
+
+// addListingIndexes adds the indexes backing the keyset ordered listings
+func addListingIndexes(db *gorm.DB) error {
+	indexes := []struct {
+		model   interface{}
+		name    string
+		columns []string
+	}{
+		{&Article{}, "idx_articles_created_at_id", []string{"created_at", "id"}},
+		{&Article{}, "idx_articles_updated_at_id", []string{"updated_at", "id"}},
+		{&Article{}, "idx_articles_favorites_count_id", []string{"favorites_count", "id"}},
+		{&Article{}, "idx_articles_comments_count_id", []string{"comments_count", "id"}},
+		{&Article{}, "idx_articles_views_count_id", []string{"views_count", "id"}},
+		{&Comment{}, "idx_comments_article_id_created_at_id", []string{"article_id", "created_at", "id"}},
+		{&Comment{}, "idx_comments_parent_id_created_at_id", []string{"parent_id", "created_at", "id"}},
+		{&Report{}, "idx_reports_status_created_at_id", []string{"status", "created_at", "id"}},
+		{&Bookmark{}, "idx_bookmarks_user_id_created_at_id", []string{"user_id", "created_at", "id"}},
+	}
+	for _, i := range indexes {
+		if err := db.Model(i.model).AddIndex(i.name, i.columns...).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dedupeSlugs suffixes duplicated article slugs with the article id,
+// so the unique slug index can be created on existing tables
+func dedupeSlugs(db *gorm.DB) error {
+	if !db.HasTable(&Article{}) {
+		return nil
+	}
+	var dups []Article
+	err := db.Unscoped().
+		Where("slug IN (?)", db.Unscoped().Table("articles").Select("slug").Group("slug").Having("COUNT(*) > 1").SubQuery()).
+		Order("id").
+		Find(&dups).Error
+	if err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for _, a := range dups {
+		if !seen[a.Slug] {
+			seen[a.Slug] = true
+			continue
+		}
+		err = db.Unscoped().Model(&Article{}).
+			Where("id = ?", a.ID).
+			UpdateColumn("slug", fmt.Sprintf("%s-%d", a.Slug, a.ID)).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dedupeFavorites removes unfavorited and duplicated favorites, so the unique
+// user and article index can be created on existing tables, it reports whether
+// any favorite was removed and the favorites counts have to be recomputed
+func dedupeFavorites(db *gorm.DB) (bool, error) {
+	if !db.HasTable(&FavoriteArticle{}) {
+		return false, nil
+	}
+	res := db.Exec("DELETE FROM favorite_articles WHERE deleted_at IS NOT NULL")
+	if res.Error != nil {
+		return false, res.Error
+	}
+	removed := res.RowsAffected
+	res = db.Exec("DELETE f1 FROM favorite_articles f1 JOIN favorite_articles f2 " +
+		"ON f1.user_id = f2.user_id AND f1.article_id = f2.article_id AND f1.id > f2.id")
+	if res.Error != nil {
+		return false, res.Error
+	}
+	removed += res.RowsAffected
+	return removed > 0, nil
+}
+
+// dedupeTags normalizes existing tag names and merges tags with the same
+// normalized name, so the unique tag name index can be created on existing tables
+func dedupeTags(db *gorm.DB) error {
+	if !db.HasTable(&Tag{}) || !db.HasTable("article_tags") {
+		return nil
+	}
+	var tags []Tag
+	if err := db.Unscoped().Order("id").Find(&tags).Error; err != nil {
+		return err
+	}
+	kept := map[string]uint{}
+	for _, t := range tags {
+		name := NormalizeTagName(t.Name)
+		keepID, ok := kept[name]
+		if !ok {
+			kept[name] = t.ID
+			if name != t.Name {
+				err := db.Unscoped().Model(&Tag{}).Where("id = ?", t.ID).UpdateColumn("name", name).Error
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		err := db.Exec(
+			"INSERT IGNORE INTO article_tags (article_id, tag_id) SELECT article_id, ? FROM article_tags WHERE tag_id = ?",
+			keepID, t.ID,
+		).Error
+		if err != nil {
+			return err
+		}
+		if err = db.Exec("DELETE FROM article_tags WHERE tag_id = ?", t.ID).Error; err != nil {
+			return err
+		}
+		if err = db.Unscoped().Delete(&Tag{}, t.ID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+)
+
+// Reaction model is the reaction of a user to an article or to one of its comments
+type Reaction struct {
+	ID        uint `gorm:"primary_key"`
+	CreatedAt time.Time
+	UserID    string `gorm:"not null;unique_index:idx_reactions_user"`
+	ArticleID uint   `gorm:"not null;unique_index:idx_reactions_user"`
+	// CommentID is 0 for reactions to the article itself
+	CommentID uint   `gorm:"not null;default:0;unique_index:idx_reactions_user"`
+	Name      string `gorm:"not null;size:64;unique_index:idx_reactions_user"`
+}
+
+// ReactionCount model is the number of reactions with a name to an article or to one of its comments
+type ReactionCount struct {
+	ArticleID uint   `gorm:"primary_key;auto_increment:false"`
+	CommentID uint   `gorm:"primary_key;auto_increment:false;index"`
+	Name      string `gorm:"primary_key;size:64"`
+	Count     int32  `gorm:"not null;default:0"`
+}
+
+// ProtoReactions generates proto reactions from the counts and the reactions of the current user
+func ProtoReactions(counts map[string]int32, reacted []string) *pb.Reactions {
+	if counts == nil {
+		counts = map[string]int32{}
+	}
+	if reacted == nil {
+		reacted = []string{}
+	}
+	return &pb.Reactions{Counts: counts, Reacted: reacted}
+}
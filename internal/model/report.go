@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/jinzhu/gorm"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+)
+
+// Report statuses
+const (
+	ReportOpen      = "open"
+	ReportDismissed = "dismissed"
+	ReportHidden    = "hidden"
+	ReportDeleted   = "deleted"
+)
+
+// Report model is a report of an article or of one of its comments by a user
+type Report struct {
+	gorm.Model
+	UserID    string `gorm:"not null"`
+	ArticleID uint   `gorm:"not null;index"`
+	Article   Article
+	// CommentID is nil for reports of the article itself
+	CommentID  *uint  `gorm:"index"`
+	Reason     string `gorm:"not null"`
+	Status     string `gorm:"not null;default:'open'"`
+	ResolvedBy string
+	ResolvedAt *time.Time
+}
+
+// Validate validates fields of report model
+func (r Report) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Reason, validation.Required, validation.Length(1, 1000)),
+	)
+}
+
+// ProtoReport generates proto report model from report
+func (r *Report) ProtoReport() *pb.Report {
+	pr := pb.Report{
+		Id:          fmt.Sprintf("%d", r.ID),
+		ArticleSlug: r.Article.Slug,
+		Reason:      r.Reason,
+		Status:      ProtoReportStatus(r.Status),
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+	}
+	if r.CommentID != nil {
+		pr.CommentId = fmt.Sprintf("%d", *r.CommentID)
+	}
+	if r.ResolvedAt != nil {
+		pr.ResolvedAt = timestamppb.New(*r.ResolvedAt)
+	}
+	return &pr
+}
+
+var protoReportStatuses = map[string]pb.ReportStatus{
+	ReportOpen:      pb.ReportStatus_REPORT_STATUS_OPEN,
+	ReportDismissed: pb.ReportStatus_REPORT_STATUS_DISMISSED,
+	ReportHidden:    pb.ReportStatus_REPORT_STATUS_HIDDEN,
+	ReportDeleted:   pb.ReportStatus_REPORT_STATUS_DELETED,
+}
+
+// ProtoReportStatus converts report status to proto report status
+func ProtoReportStatus(status string) pb.ReportStatus {
+	return protoReportStatuses[status]
+}
+
+// ReportStatusFromProto converts proto report status to report status,
+// it returns an empty string for unspecified status
+func ReportStatusFromProto(status pb.ReportStatus) string {
+	for s, ps := range protoReportStatuses {
+		if ps == status {
+			return s
+		}
+	}
+	return ""
+}
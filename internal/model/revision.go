@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+)
+
+// ArticleRevision model is an immutable snapshot of an article after a change
+type ArticleRevision struct {
+	gorm.Model
+	ArticleID   uint   `gorm:"not null;unique_index:idx_article_revisions_number"`
+	Number      int32  `gorm:"not null;unique_index:idx_article_revisions_number"`
+	UserID      string `gorm:"not null"`
+	Title       string `gorm:"not null"`
+	Description string `gorm:"not null"`
+	Body        string `gorm:"not null"`
+	Fields      string `gorm:"not null"`
+	// TagNames is the json list of the tag names, nil for revisions stored before tags were kept
+	TagNames *string `gorm:"type:text"`
+}
+
+// NewArticleRevision creates a revision from the current content of the article
+func NewArticleRevision(a *Article, userID string, fields []string) ArticleRevision {
+	return ArticleRevision{
+		ArticleID:   a.ID,
+		UserID:      userID,
+		Title:       a.Title,
+		Description: a.Description,
+		Body:        a.Body,
+		Fields:      strings.Join(fields, ","),
+		TagNames:    tagNamesJSON(a.Tags),
+	}
+}
+
+func tagNamesJSON(tags []Tag) *string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	b, _ := json.Marshal(names)
+	s := string(b)
+	return &s
+}
+
+// TagList returns the tag names of the revision,
+// ok is false when the revision was stored without them
+func (r *ArticleRevision) TagList() (names []string, ok bool) {
+	if r.TagNames == nil {
+		return nil, false
+	}
+	if err := json.Unmarshal([]byte(*r.TagNames), &names); err != nil {
+		return nil, false
+	}
+	return names, true
+}
+
+// ChangedFields returns the article fields changed in the revision
+func (r *ArticleRevision) ChangedFields() []string {
+	if r.Fields == "" {
+		return []string{}
+	}
+	return strings.Split(r.Fields, ",")
+}
+
+// ProtoRevision generates proto revision model from revision
+func (r *ArticleRevision) ProtoRevision() *pb.Revision {
+	tagList, _ := r.TagList()
+	return &pb.Revision{
+		Number:      r.Number,
+		Title:       r.Title,
+		Description: r.Description,
+		Body:        r.Body,
+		UserID:      r.UserID,
+		Fields:      r.ChangedFields(),
+		TagList:     tagList,
+		CreatedAt:   timestamppb.New(r.CreatedAt),
+	}
+}
@@ -0,0 +1,22 @@
+package model
+
+import "testing"
+
+func TestRevisionTagList(t *testing.T) {
+	a := &Article{Title: "Title", Tags: []Tag{{Name: "go"}, {Name: "c++"}}}
+	r := NewArticleRevision(a, "author", []string{FieldTags})
+	names, ok := r.TagList()
+	if !ok || len(names) != 2 || names[0] != "go" || names[1] != "c++" {
+		t.Errorf("TagList = %v, %v, want [go c++], true", names, ok)
+	}
+
+	untagged := NewArticleRevision(&Article{Title: "Title"}, "author", nil)
+	if names, ok := untagged.TagList(); !ok || len(names) != 0 {
+		t.Errorf("TagList of an untagged article = %v, %v, want [], true", names, ok)
+	}
+
+	var old ArticleRevision
+	if _, ok := old.TagList(); ok {
+		t.Errorf("TagList of a revision stored without tags is ok")
+	}
+}
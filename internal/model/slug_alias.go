@@ -0,0 +1,10 @@
+package model
+
+import "github.com/jinzhu/gorm"
+
+// SlugAlias model keeps a previous slug of an article resolvable
+type SlugAlias struct {
+	gorm.Model
+	Slug      string `gorm:"not null;unique_index"`
+	ArticleID uint   `gorm:"not null;index"`
+}
@@ -1,9 +1,42 @@
 package model
 
-import "github.com/jinzhu/gorm"
+import (
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	pb "github.com/rezaAmiri123/service-article/gen/pb"
+)
 
 // Tag model
 type Tag struct {
 	gorm.Model
-	Name string `gorm:"not null"`
+	Name string `gorm:"not null;unique_index"`
+}
+
+// TagAlias model is a synonym which resolves to a tag
+type TagAlias struct {
+	gorm.Model
+	Name  string `gorm:"not null;unique_index"`
+	TagID uint   `gorm:"not null;index"`
+}
+
+// NormalizeTagName case-folds a tag name, trims it and collapses its inner whitespace,
+// punctuation is kept so names such as "c", "c++" and "c#" stay distinct
+func NormalizeTagName(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// TagCount is a tag with the number of published articles using it
+type TagCount struct {
+	Name          string
+	ArticlesCount int32
+}
+
+// ProtoTag generates proto tag model from tag count
+func (t *TagCount) ProtoTag() *pb.Tag {
+	return &pb.Tag{
+		Name:          t.Name,
+		ArticlesCount: t.ArticlesCount,
+	}
 }
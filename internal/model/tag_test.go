@@ -0,0 +1,23 @@
+package model
+
+import "testing"
+
+func TestNormalizeTagName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Go", want: "go"},
+		{name: "  GoLang \t", want: "golang"},
+		{name: "Machine   Learning", want: "machine learning"},
+		{name: "C", want: "c"},
+		{name: "C++", want: "c++"},
+		{name: "C#", want: "c#"},
+		{name: " \t", want: ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeTagName(tt.name); got != tt.want {
+			t.Errorf("NormalizeTagName(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/opentracing/opentracing-go"
@@ -10,20 +13,65 @@ import (
 	"github.com/rezaAmiri123/service-article/pkg/utils"
 )
 
+// ErrVersionConflict is returned when an article has been changed since it was read
+var ErrVersionConflict = errors.New("article has been modified")
+
+// ArticleFilter filters the articles listed by GetArticles
+type ArticleFilter struct {
+	// ViewerID sees their own unpublished articles
+	ViewerID  string
+	AuthorIDs []string
+	Tags      []string
+	// MatchAllTags lists articles having all the tags instead of any of them
+	MatchAllTags  bool
+	ExcludeTags   []string
+	FavoritedByID string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// CommentFilter filters the comments listed by GetComments and GetReplies
+type CommentFilter struct {
+	// ViewerID sees their own hidden comments
+	ViewerID string
+	// ShowHidden lists hidden comments of every user
+	ShowHidden bool
+	// TopLevelOnly leaves out the replies to comments
+	TopLevelOnly bool
+}
+
 type ArticleRepository interface {
 	Create(ctx context.Context, article *model.Article) error
-	Update(ctx context.Context, article *model.Article) error
+	Update(ctx context.Context, article *model.Article, editorID string, fields []string) error
 	Delete(ctx context.Context, article *model.Article) error
 	GetBySlug(ctx context.Context, slug string) (*model.Article, error)
 	GetByID(ctx context.Context, id string) (*model.Article, error)
-	GetArticles(ctx context.Context, authorID, tagName, favoritedByID string, limit, offset int64) ([]model.Article, error)
+	GetArticles(ctx context.Context, filter ArticleFilter, order Order, page Page) ([]model.Article, string, error)
+	CountArticles(ctx context.Context, filter ArticleFilter) (int64, error)
+	SetStatus(ctx context.Context, article *model.Article, status string) error
+	PublishDue(ctx context.Context, now time.Time, limit int) (int64, error)
 	CreateComment(ctx context.Context, comment *model.Comment) error
 	GetCommentByID(ctx context.Context, id string) (*model.Comment, error)
-	GetComments(ctx context.Context, article *model.Article) ([]model.Comment, error)
+	GetComments(ctx context.Context, article *model.Article, filter CommentFilter, page Page) ([]model.Comment, string, error)
+	GetReplies(ctx context.Context, comment *model.Comment, filter CommentFilter, page Page) ([]model.Comment, string, error)
+	UpdateComment(ctx context.Context, comment *model.Comment, body string) error
 	DeleteComment(ctx context.Context, comment *model.Comment) error
+	TombstoneComment(ctx context.Context, comment *model.Comment) error
+	HideComment(ctx context.Context, comment *model.Comment) error
 	AddFavorite(ctx context.Context, article *model.Article, userID string) error
 	DeleteFavorite(ctx context.Context, article *model.Article, userID string) error
 	IsFavorited(ctx context.Context, article *model.Article, userID string) (bool, error)
+	FavoritedSet(ctx context.Context, articleIDs []uint, userID string) (map[uint]bool, error)
+	GetDeletedBySlug(ctx context.Context, slug string) (*model.Article, error)
+	ListDeleted(ctx context.Context, userID string, limit, offset int64) ([]model.Article, error)
+	CountDeleted(ctx context.Context, userID string) (int64, error)
+	Restore(ctx context.Context, article *model.Article) error
+	Purge(ctx context.Context, article *model.Article) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	ListRevisions(ctx context.Context, article *model.Article, limit, offset int64) ([]model.ArticleRevision, error)
+	GetRevision(ctx context.Context, article *model.Article, number int32) (*model.ArticleRevision, error)
+	ReconcileCounters(ctx context.Context, afterID uint, limit int, fix bool) ([]CounterDrift, uint, error)
+	AddViews(ctx context.Context, views map[uint]int64) error
 }
 
 type ORMArticleRepository struct {
@@ -38,14 +86,53 @@ func (repo *ORMArticleRepository) Create(ctx context.Context, article *model.Art
 	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.Create")
 	defer span.Finish()
 
-	return repo.db.Create(article).Error
+	tx := repo.db.Begin()
+	if err := createTags(tx, article.Tags); err != nil {
+		tx.Rollback()
+		return err
+	}
+	err := saveUniqueSlug(tx, article.Slug, 0, func(slug string) error {
+		article.Slug = slug
+		return tx.Create(article).Error
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	fields := []string{model.FieldTitle, model.FieldDescription, model.FieldBody}
+	if err := createRevision(tx, article, article.UserID, fields); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
 }
 
 func (repo *ORMArticleRepository) CreateComment(ctx context.Context, comment *model.Comment) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.CreateComment")
 	defer span.Finish()
 
-	return repo.db.Create(comment).Error
+	tx := repo.db.Begin()
+	if err := tx.Create(comment).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	err := tx.Model(&model.Article{}).
+		Where("id = ?", comment.ArticleID).
+		UpdateColumn("comments_count", gorm.Expr("comments_count + ?", 1)).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if comment.ParentID != nil {
+		err = tx.Model(&model.Comment{}).
+			Where("id = ?", *comment.ParentID).
+			UpdateColumn("replies_count", gorm.Expr("replies_count + ?", 1)).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
 }
 
 func (repo *ORMArticleRepository) GetBySlug(ctx context.Context, slug string) (*model.Article, error) {
@@ -53,7 +140,18 @@ func (repo *ORMArticleRepository) GetBySlug(ctx context.Context, slug string) (*
 	defer span.Finish()
 
 	var a model.Article
-	if err := repo.db.Where(model.Article{Slug: slug}).First(&a).Error; err != nil {
+	err := repo.db.Preload("Tags").Where(model.Article{Slug: slug}).First(&a).Error
+	if gorm.IsRecordNotFoundError(err) {
+		// fall back to the previous slugs of articles
+		var alias model.SlugAlias
+		aliasErr := repo.db.Where(model.SlugAlias{Slug: slug}).First(&alias).Error
+		if aliasErr == nil {
+			err = repo.db.Preload("Tags").First(&a, alias.ArticleID).Error
+		} else if !gorm.IsRecordNotFoundError(aliasErr) {
+			err = aliasErr
+		}
+	}
+	if err != nil {
 		return nil, err
 	}
 	return &a, nil
@@ -70,11 +168,117 @@ func (repo *ORMArticleRepository) GetByID(ctx context.Context, id string) (*mode
 	return &a, nil
 }
 
-func (repo *ORMArticleRepository) Update(ctx context.Context, article *model.Article) error {
+// Update saves the article and records a revision when any of the given content fields changed
+func (repo *ORMArticleRepository) Update(ctx context.Context, article *model.Article, editorID string, fields []string) error {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.Update")
 	defer span.Finish()
 
-	return repo.db.Model(article).Update(article).Error
+	tx := repo.db.Begin()
+	if err := updateSlug(tx, article); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(fields) > 0 {
+		// articles created before revisions existed get their stored content as the first revision
+		if err := createBaseRevision(tx, article.ID); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	res := tx.Model(article).
+		Set("gorm:save_associations", false).
+		Where("version = ?", article.Version).
+		Updates(map[string]interface{}{
+			"title":       article.Title,
+			"slug":        article.Slug,
+			"description": article.Description,
+			"body":        article.Body,
+			"status":      article.Status,
+			"publish_at":  article.PublishAt,
+			"version":     gorm.Expr("version + ?", 1),
+		})
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrVersionConflict
+	}
+	article.Version++
+	for _, f := range fields {
+		if f != model.FieldTags {
+			continue
+		}
+		if err := createTags(tx, article.Tags); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := tx.Model(article).Association("Tags").Replace(article.Tags).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if len(fields) > 0 {
+		if err := createRevision(tx, article, editorID, fields); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+func (repo *ORMArticleRepository) SetStatus(ctx context.Context, article *model.Article, status string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.SetStatus")
+	defer span.Finish()
+
+	err := repo.db.Model(article).Updates(map[string]interface{}{
+		"status":  status,
+		"version": gorm.Expr("version + ?", 1),
+	}).Error
+	if err != nil {
+		return err
+	}
+	article.Version++
+	return nil
+}
+
+// PublishDue publishes up to limit scheduled articles whose publish time has passed,
+// claimed rows are locked and skipped by other replicas running concurrently
+func (repo *ORMArticleRepository) PublishDue(ctx context.Context, now time.Time, limit int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.PublishDue")
+	defer span.Finish()
+
+	tx := repo.db.Begin()
+	var ids []uint
+	err := tx.Set("gorm:query_option", "FOR UPDATE SKIP LOCKED").
+		Model(&model.Article{}).
+		Where("status = ? AND publish_at <= ?", model.StatusScheduled, now).
+		Order("publish_at").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if len(ids) == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+	res := tx.Model(&model.Article{}).
+		Where("id IN (?)", ids).
+		Updates(map[string]interface{}{
+			"status":  model.StatusPublished,
+			"version": gorm.Expr("version + ?", 1),
+		})
+	if res.Error != nil {
+		tx.Rollback()
+		return 0, res.Error
+	}
+	if err = tx.Commit().Error; err != nil {
+		return 0, err
+	}
+	return res.RowsAffected, nil
 }
 
 func (repo *ORMArticleRepository) Delete(ctx context.Context, article *model.Article) error {
@@ -96,110 +300,355 @@ func (repo *ORMArticleRepository) GetCommentByID(ctx context.Context, id string)
 	return &m, nil
 }
 
-func (repo *ORMArticleRepository) GetArticles(ctx context.Context, authorID, tagName, favoritedByID string, limit, offset int64) ([]model.Article, error) {
+// GetArticles returns a page of the filtered articles in the given order,
+// along with the token of the next page
+func (repo *ORMArticleRepository) GetArticles(ctx context.Context, filter ArticleFilter, order Order, page Page) ([]model.Article, string, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.GetArticles")
 	defer span.Finish()
 
-	d := repo.db
-	if tagName != "" {
-		d = d.Where("tags.name = ?", tagName)
-	}
-	if authorID !=""{
-		d = d.Where("user_id = ?", authorID)
-	}
-	if favoritedByID != "" {
-		rows, err := repo.db.Select("article_id").
-			Table("favorite_articles").
-			Where("user_id = ?", favoritedByID).
-			Offset(offset).Limit(limit).Rows()
-		if err != nil {
-			return []model.Article{}, err
-		}
-		defer rows.Close()
-		var ids []uint
-		for rows.Next() {
-			var id uint
-			rows.Scan(&id)
-			ids = append(ids, id)
-		}
-		d = d.Where("id in (?)", ids)
+	d, err := paginate(repo.filterArticles(filter), "articles", order, page)
+	if err != nil {
+		return nil, "", err
 	}
-	d = d.Offset(offset).Limit(limit)
 	var as []model.Article
-	err := d.Find(&as).Error
-	return as, err
+	if err = d.Preload("Tags").Find(&as).Error; err != nil {
+		return nil, "", err
+	}
+	token, n := nextPageToken(page, order, len(as), func(i int) cursor {
+		return articleCursor(order, &as[i])
+	})
+	return as[:n], token, nil
 }
 
-func (repo *ORMArticleRepository) DeleteComment(ctx context.Context, comment *model.Comment) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.DeleteComment")
+// CountArticles returns the number of articles matching the filter across all pages
+func (repo *ORMArticleRepository) CountArticles(ctx context.Context, filter ArticleFilter) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.CountArticles")
 	defer span.Finish()
 
-	return repo.db.Delete(comment).Error
+	var count int64
+	err := repo.filterArticles(filter).Count(&count).Error
+	return count, err
 }
 
-func (repo *ORMArticleRepository) GetComments(ctx context.Context, article *model.Article) ([]model.Comment, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.GetComments")
-	defer span.Finish()
+// articleCursor returns the position of the article in a listing in the given order
+func articleCursor(order Order, a *model.Article) cursor {
+	c := cursor{ID: a.ID}
+	switch order {
+	case OrderMostFavorited:
+		c.Count = int64(a.FavoritesCount)
+	case OrderMostCommented:
+		c.Count = int64(a.CommentsCount)
+	case OrderMostViewed:
+		c.Count = a.ViewsCount
+	case OrderRecentlyUpdated:
+		c.Time = a.UpdatedAt
+	default:
+		c.Time = a.CreatedAt
+	}
+	return c
+}
 
-	var cs []model.Comment
-	err := repo.db.Where(model.Comment{
-		ArticleID: article.ID,
-	}).Find(&cs).Error
-	if err != nil {
-		return nil, err
+// filterArticles applies the filter to a query on articles
+func (repo *ORMArticleRepository) filterArticles(filter ArticleFilter) *gorm.DB {
+	// only published articles are listed, except for the author's own
+	d := repo.db.Model(&model.Article{}).
+		Where("articles.status = ? OR articles.user_id = ?", model.StatusPublished, filter.ViewerID)
+	if len(filter.AuthorIDs) > 0 {
+		d = d.Where("articles.user_id IN (?)", filter.AuthorIDs)
+	}
+	if len(filter.Tags) > 0 {
+		if filter.MatchAllTags {
+			for _, t := range filter.Tags {
+				d = d.Where("articles.id IN (?)", taggedArticleIDs(repo.db, []string{t}))
+			}
+		} else {
+			d = d.Where("articles.id IN (?)", taggedArticleIDs(repo.db, filter.Tags))
+		}
+	}
+	if len(filter.ExcludeTags) > 0 {
+		d = d.Where("articles.id NOT IN (?)", taggedArticleIDs(repo.db, filter.ExcludeTags))
+	}
+	if filter.FavoritedByID != "" {
+		favorited := repo.db.Model(&model.FavoriteArticle{}).
+			Select("article_id").
+			Where("user_id = ?", filter.FavoritedByID).
+			SubQuery()
+		d = d.Where("articles.id IN (?)", favorited)
+	}
+	if filter.CreatedAfter != nil {
+		d = d.Where("articles.created_at >= ?", *filter.CreatedAfter)
 	}
-	return cs, nil
+	if filter.CreatedBefore != nil {
+		d = d.Where("articles.created_at < ?", *filter.CreatedBefore)
+	}
+	return d
 }
 
-func (repo *ORMArticleRepository) AddFavorite(ctx context.Context, article *model.Article, userID string) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.AddFavorite")
+// UpdateComment replaces the body of the comment and keeps the previous body as a revision
+func (repo *ORMArticleRepository) UpdateComment(ctx context.Context, comment *model.Comment, body string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.UpdateComment")
 	defer span.Finish()
 
+	now := time.Now()
 	tx := repo.db.Begin()
-	fav := model.FavoriteArticle{UserID: userID, ArticleID: article.ID}
-	//err := tx.Model(article).Association("FavoritedUsers").Append(userID).Error
-	err := tx.Model(article).Association("Favorited").Append(fav).Error
+	err := tx.Create(&model.CommentRevision{CommentID: comment.ID, Body: comment.Body}).Error
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	err = tx.Model(article).Update("favorites_count", gorm.Expr("favorites_count + ?", 1)).Error
+	err = tx.Model(comment).Set("gorm:save_associations", false).Updates(map[string]interface{}{
+		"body":      body,
+		"edited_at": now,
+	}).Error
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	tx.Commit()
-	article.FavoritesCount++
+	if err = tx.Commit().Error; err != nil {
+		return err
+	}
+	comment.Body = body
+	comment.EditedAt = &now
 	return nil
 }
 
-func (repo *ORMArticleRepository) DeleteFavorite(ctx context.Context, article *model.Article, userID string) error {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.DeleteFavorite")
+// DeleteComment deletes the comment along with all of its replies
+func (repo *ORMArticleRepository) DeleteComment(ctx context.Context, comment *model.Comment) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.DeleteComment")
 	defer span.Finish()
 
 	tx := repo.db.Begin()
-	// TODO it needs review
-	//err := tx.Model(article).Association("FavoritedUsers").Delete(userID).Error
-	filter := model.FavoriteArticle{UserID: userID, ArticleID: article.ID}
-	obj := model.FavoriteArticle{}
-	err :=tx.Delete(&obj, filter).Error
-	//err := tx.Model(article).Association("Favorited").Delete(obj).Error
+	ids, err := commentThreadIDs(tx, comment.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	res := tx.Where("id IN (?)", ids).Delete(&model.Comment{})
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		err := tx.Model(&model.Article{}).
+			Where("id = ?", comment.ArticleID).
+			UpdateColumn("comments_count", gorm.Expr("comments_count - ?", res.RowsAffected)).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if res.RowsAffected > 0 && comment.ParentID != nil {
+		err := tx.Model(&model.Comment{}).
+			Where("id = ?", *comment.ParentID).
+			UpdateColumn("replies_count", gorm.Expr("replies_count - ?", 1)).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// TombstoneComment wipes the content and the edit history of the comment
+// but keeps it as a placeholder in the thread of its replies
+func (repo *ORMArticleRepository) TombstoneComment(ctx context.Context, comment *model.Comment) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.TombstoneComment")
+	defer span.Finish()
 
+	tx := repo.db.Begin()
+	err := tx.Unscoped().Where("comment_id = ?", comment.ID).Delete(&model.CommentRevision{}).Error
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	err = tx.Model(article).Update("favorites_count", gorm.Expr("favorites_count - ?", 1)).Error
+	err = tx.Model(comment).Set("gorm:save_associations", false).Updates(map[string]interface{}{
+		"body":       "",
+		"edited_at":  nil,
+		"tombstoned": true,
+	}).Error
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
-	tx.Commit()
-	article.FavoritesCount--
+	if err = tx.Commit().Error; err != nil {
+		return err
+	}
+	comment.Body = ""
+	comment.EditedAt = nil
+	comment.Tombstoned = true
+	return nil
+}
 
+// HideComment hides the comment from everyone but moderators and its author
+func (repo *ORMArticleRepository) HideComment(ctx context.Context, comment *model.Comment) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.HideComment")
+	defer span.Finish()
+
+	err := repo.db.Model(comment).UpdateColumn("hidden", true).Error
+	if err != nil {
+		return err
+	}
+	comment.Hidden = true
 	return nil
 }
 
+// commentThreadIDs returns the id of the comment and the ids of all its nested replies
+func commentThreadIDs(tx *gorm.DB, id uint) ([]uint, error) {
+	ids := []uint{id}
+	for level := ids; len(level) > 0; {
+		var replies []uint
+		err := tx.Model(&model.Comment{}).Where("parent_id IN (?)", level).Pluck("id", &replies).Error
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, replies...)
+		level = replies
+	}
+	return ids, nil
+}
+
+// GetComments returns a page of the comments of the article, oldest first,
+// along with the token of the next page
+func (repo *ORMArticleRepository) GetComments(ctx context.Context, article *model.Article, filter CommentFilter, page Page) ([]model.Comment, string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.GetComments")
+	defer span.Finish()
+
+	d := repo.db.Where(model.Comment{
+		ArticleID: article.ID,
+	})
+	if filter.TopLevelOnly {
+		d = d.Where("parent_id IS NULL")
+	}
+	return commentsPage(filterComments(d, filter), page)
+}
+
+// GetReplies returns a page of the direct replies to the comment, oldest first,
+// along with the token of the next page
+func (repo *ORMArticleRepository) GetReplies(ctx context.Context, comment *model.Comment, filter CommentFilter, page Page) ([]model.Comment, string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.GetReplies")
+	defer span.Finish()
+
+	d := repo.db.Where("parent_id = ?", comment.ID)
+	return commentsPage(filterComments(d, filter), page)
+}
+
+// filterComments leaves out the hidden comments the filter does not show
+func filterComments(d *gorm.DB, filter CommentFilter) *gorm.DB {
+	if filter.ShowHidden {
+		return d
+	}
+	return d.Where("comments.hidden = ? OR comments.user_id = ?", false, filter.ViewerID)
+}
+
+func commentsPage(d *gorm.DB, page Page) ([]model.Comment, string, error) {
+	d, err := paginate(d, "comments", OrderOldest, page)
+	if err != nil {
+		return nil, "", err
+	}
+	var cs []model.Comment
+	if err = d.Find(&cs).Error; err != nil {
+		return nil, "", err
+	}
+	token, n := nextPageToken(page, OrderOldest, len(cs), func(i int) cursor {
+		return cursor{Time: cs[i].CreatedAt, ID: cs[i].ID}
+	})
+	return cs[:n], token, nil
+}
+
+// AddFavorite favorites the article for the user, favoriting it again is a no-op,
+// the favorites count of the article is updated to its current value
+func (repo *ORMArticleRepository) AddFavorite(ctx context.Context, article *model.Article, userID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.AddFavorite")
+	defer span.Finish()
+
+	return retryTx(repo.db, func(tx *gorm.DB) error {
+		if err := lockArticle(tx, article.ID); err != nil {
+			return err
+		}
+		fav := model.FavoriteArticle{UserID: userID, ArticleID: article.ID}
+		// the unique user and article index makes concurrent favorites of the same user a no-op
+		res := tx.Set("gorm:insert_modifier", "IGNORE").
+			Set("gorm:save_associations", false).
+			Create(&fav)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			err := tx.Model(&model.Article{}).
+				Where("id = ?", article.ID).
+				UpdateColumn("favorites_count", gorm.Expr("favorites_count + ?", 1)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return loadFavoritesCount(tx, article)
+	})
+}
+
+// DeleteFavorite unfavorites the article for the user, unfavoriting it again is a no-op,
+// the favorites count of the article is updated to its current value
+func (repo *ORMArticleRepository) DeleteFavorite(ctx context.Context, article *model.Article, userID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.DeleteFavorite")
+	defer span.Finish()
+
+	return retryTx(repo.db, func(tx *gorm.DB) error {
+		if err := lockArticle(tx, article.ID); err != nil {
+			return err
+		}
+		res := tx.Unscoped().
+			Where("user_id = ? AND article_id = ?", userID, article.ID).
+			Delete(&model.FavoriteArticle{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected > 0 {
+			err := tx.Model(&model.Article{}).
+				Where("id = ? AND favorites_count > 0", article.ID).
+				UpdateColumn("favorites_count", gorm.Expr("favorites_count - ?", 1)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return loadFavoritesCount(tx, article)
+	})
+}
+
+// lockArticle locks the row of the article until the end of the transaction,
+// favorites of the same article take it first so they wait for each other instead of deadlocking
+func lockArticle(tx *gorm.DB, id uint) error {
+	var a model.Article
+	return tx.Unscoped().Set("gorm:query_option", "FOR UPDATE").Select("id").First(&a, id).Error
+}
+
+// AddViews adds the numbers of views to the view counts of the articles in a single statement
+func (repo *ORMArticleRepository) AddViews(ctx context.Context, views map[uint]int64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.AddViews")
+	defer span.Finish()
+
+	if len(views) == 0 {
+		return nil
+	}
+	ids := make([]uint, 0, len(views))
+	cases := make([]string, 0, len(views))
+	args := make([]interface{}, 0, 2*len(views))
+	for id, n := range views {
+		ids = append(ids, id)
+		cases = append(cases, "WHEN ? THEN ?")
+		args = append(args, id, n)
+	}
+	expr := gorm.Expr("views_count + CASE id "+strings.Join(cases, " ")+" ELSE 0 END", args...)
+	return repo.db.Unscoped().Model(&model.Article{}).Where("id IN (?)", ids).UpdateColumn("views_count", expr).Error
+}
+
+// loadFavoritesCount reads the stored favorites count of the article
+func loadFavoritesCount(tx *gorm.DB, article *model.Article) error {
+	return tx.Model(&model.Article{}).
+		Select("favorites_count").
+		Where("id = ?", article.ID).
+		Row().
+		Scan(&article.FavoritesCount)
+}
+
 func (repo *ORMArticleRepository) IsFavorited(ctx context.Context, article *model.Article, userID string) (bool, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.IsFavorited")
 	defer span.Finish()
@@ -212,9 +661,32 @@ func (repo *ORMArticleRepository) IsFavorited(ctx context.Context, article *mode
 	filter := model.FavoriteArticle{}
 	filter.ArticleID = article.ID
 	filter.UserID = userID
-	err := repo.db.Table("favorite_articles").Where(filter).Count(&count).Error
+	err := repo.db.Model(&model.FavoriteArticle{}).Where(filter).Count(&count).Error
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
+
+// FavoritedSet returns which of the articles are favorited by the user in a single query
+func (repo *ORMArticleRepository) FavoritedSet(ctx context.Context, articleIDs []uint, userID string) (map[uint]bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.FavoritedSet")
+	defer span.Finish()
+
+	favorited := make(map[uint]bool, len(articleIDs))
+	if len(articleIDs) == 0 || userID == "" {
+		return favorited, nil
+	}
+
+	var ids []uint
+	err := repo.db.Model(&model.FavoriteArticle{}).
+		Where("user_id = ? AND article_id IN (?)", userID, articleIDs).
+		Pluck("article_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		favorited[id] = true
+	}
+	return favorited, nil
+}
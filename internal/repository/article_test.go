@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/testdb"
+)
+
+func TestArticleFilter(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewORMArticleRepository(db)
+
+	articles := []struct {
+		title     string
+		userID    string
+		tags      []string
+		createdAt time.Time
+	}{
+		{"Go basics", "alice", []string{"go", "beginner"}, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"Go concurrency", "bob", []string{"go", "concurrency"}, time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"Rust intro", "carol", []string{"rust", "beginner"}, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, a := range articles {
+		article := createTestArticle(t, db, a.title, a.userID, a.tags...)
+		err := db.Model(article).UpdateColumn("created_at", a.createdAt).Error
+		if err != nil {
+			t.Fatalf("set created_at of %s: %v", a.title, err)
+		}
+	}
+	goTag, err := NewORMTagRepository(db).GetByName(context.Background(), "go")
+	if err != nil {
+		t.Fatalf("get tag go: %v", err)
+	}
+	if err = NewORMTagRepository(db).AddAlias(context.Background(), goTag, "golang"); err != nil {
+		t.Fatalf("add alias golang: %v", err)
+	}
+
+	after := time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter ArticleFilter
+		want   []string
+	}{
+		{
+			name: "no filter",
+			want: []string{"Go basics", "Go concurrency", "Rust intro"},
+		},
+		{
+			name:   "any tag",
+			filter: ArticleFilter{Tags: []string{"concurrency", "rust"}},
+			want:   []string{"Go concurrency", "Rust intro"},
+		},
+		{
+			name:   "all tags",
+			filter: ArticleFilter{Tags: []string{"go", "beginner"}, MatchAllTags: true},
+			want:   []string{"Go basics"},
+		},
+		{
+			name:   "all tags without match",
+			filter: ArticleFilter{Tags: []string{"go", "rust"}, MatchAllTags: true},
+			want:   []string{},
+		},
+		{
+			name:   "exclude tags",
+			filter: ArticleFilter{ExcludeTags: []string{"beginner"}},
+			want:   []string{"Go concurrency"},
+		},
+		{
+			name:   "tags and exclude tags",
+			filter: ArticleFilter{Tags: []string{"beginner"}, ExcludeTags: []string{"rust"}},
+			want:   []string{"Go basics"},
+		},
+		{
+			name:   "alias",
+			filter: ArticleFilter{Tags: []string{"golang"}},
+			want:   []string{"Go basics", "Go concurrency"},
+		},
+		{
+			name:   "alias in all tags",
+			filter: ArticleFilter{Tags: []string{"Golang", "beginner"}, MatchAllTags: true},
+			want:   []string{"Go basics"},
+		},
+		{
+			name:   "alias in exclude tags",
+			filter: ArticleFilter{ExcludeTags: []string{"golang"}},
+			want:   []string{"Rust intro"},
+		},
+		{
+			name:   "authors",
+			filter: ArticleFilter{AuthorIDs: []string{"alice", "carol"}},
+			want:   []string{"Go basics", "Rust intro"},
+		},
+		{
+			name:   "created range",
+			filter: ArticleFilter{CreatedAfter: &after, CreatedBefore: &before},
+			want:   []string{"Go concurrency"},
+		},
+		{
+			name:   "authors and tags",
+			filter: ArticleFilter{AuthorIDs: []string{"alice", "carol"}, Tags: []string{"go"}},
+			want:   []string{"Go basics"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as, _, err := repo.GetArticles(context.Background(), tt.filter, OrderOldest, Page{Limit: 10})
+			if err != nil {
+				t.Fatalf("GetArticles: %v", err)
+			}
+			got := make([]string, 0, len(as))
+			for _, a := range as {
+				got = append(got, a.Title)
+			}
+			if !equalTitles(got, tt.want) {
+				t.Errorf("GetArticles = %v, want %v", got, tt.want)
+			}
+
+			count, err := repo.CountArticles(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("CountArticles: %v", err)
+			}
+			if count != int64(len(tt.want)) {
+				t.Errorf("CountArticles = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestArticleFilterUnpublished(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewORMArticleRepository(db)
+
+	createTestArticle(t, db, "Published", "alice")
+	draft := createTestArticle(t, db, "Draft", "alice")
+	if err := db.Model(draft).UpdateColumn("status", model.StatusDraft).Error; err != nil {
+		t.Fatalf("set status: %v", err)
+	}
+
+	for viewerID, want := range map[string]int64{"": 1, "bob": 1, "alice": 2} {
+		count, err := repo.CountArticles(context.Background(), ArticleFilter{ViewerID: viewerID})
+		if err != nil {
+			t.Fatalf("CountArticles: %v", err)
+		}
+		if count != want {
+			t.Errorf("CountArticles for viewer %q = %d, want %d", viewerID, count, want)
+		}
+	}
+}
+
+func equalTitles(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestGetComments(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewORMArticleRepository(db)
+	article := createTestArticle(t, db, "Commented", "author")
+
+	top := &model.Comment{Body: "top", UserID: "alice", ArticleID: article.ID}
+	if err := repo.CreateComment(context.Background(), top); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+	reply := &model.Comment{Body: "reply", UserID: "bob", ArticleID: article.ID, ParentID: &top.ID, Depth: 1}
+	if err := repo.CreateComment(context.Background(), reply); err != nil {
+		t.Fatalf("CreateComment: %v", err)
+	}
+
+	for _, tt := range []struct {
+		topLevelOnly bool
+		want         []string
+	}{
+		{topLevelOnly: false, want: []string{"top", "reply"}},
+		{topLevelOnly: true, want: []string{"top"}},
+	} {
+		filter := CommentFilter{TopLevelOnly: tt.topLevelOnly}
+		cs, _, err := repo.GetComments(context.Background(), article, filter, Page{})
+		if err != nil {
+			t.Fatalf("GetComments: %v", err)
+		}
+		got := make([]string, 0, len(cs))
+		for _, c := range cs {
+			got = append(got, c.Body)
+		}
+		if !equalTitles(got, tt.want) {
+			t.Errorf("GetComments(topLevelOnly %t) = %v, want %v", tt.topLevelOnly, got, tt.want)
+		}
+	}
+}
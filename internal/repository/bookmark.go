@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+type BookmarkRepository interface {
+	Add(ctx context.Context, bookmark *model.Bookmark) error
+	Remove(ctx context.Context, userID string, articleID uint) error
+	List(ctx context.Context, userID, folder string, page Page) ([]model.Bookmark, string, error)
+}
+
+type ORMBookmarkRepository struct {
+	db *gorm.DB
+}
+
+func NewORMBookmarkRepository(db *gorm.DB) *ORMBookmarkRepository {
+	return &ORMBookmarkRepository{db: db}
+}
+
+// Add bookmarks the article for the user, an existing bookmark is moved to the folder of the new one
+func (repo *ORMBookmarkRepository) Add(ctx context.Context, bookmark *model.Bookmark) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMBookmarkRepository.Add")
+	defer span.Finish()
+
+	err := repo.db.
+		Set("gorm:insert_option", "ON DUPLICATE KEY UPDATE folder = VALUES(folder), updated_at = VALUES(updated_at)").
+		Set("gorm:save_associations", false).
+		Create(bookmark).Error
+	if err != nil {
+		return err
+	}
+	// the stored bookmark keeps its id and creation time when it was moved
+	return repo.db.
+		Where("user_id = ? AND article_id = ?", bookmark.UserID, bookmark.ArticleID).
+		First(bookmark).Error
+}
+
+// Remove removes the bookmark of the article for the user, removing a missing bookmark is a no-op
+func (repo *ORMBookmarkRepository) Remove(ctx context.Context, userID string, articleID uint) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMBookmarkRepository.Remove")
+	defer span.Finish()
+
+	return repo.db.Where("user_id = ? AND article_id = ?", userID, articleID).Delete(&model.Bookmark{}).Error
+}
+
+// List returns a page of the bookmarks of the user, newest first, along with the token of the next page,
+// bookmarks of a folder are listed if it is set and bookmarks of articles the user can't see are left out
+func (repo *ORMBookmarkRepository) List(ctx context.Context, userID, folder string, page Page) ([]model.Bookmark, string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMBookmarkRepository.List")
+	defer span.Finish()
+
+	d := repo.db.
+		Joins("JOIN articles ON articles.id = bookmarks.article_id AND articles.deleted_at IS NULL").
+		Where("bookmarks.user_id = ?", userID).
+		Where("articles.status = ? OR articles.user_id = ?", model.StatusPublished, userID)
+	if folder != "" {
+		d = d.Where("bookmarks.folder = ?", folder)
+	}
+	d, err := paginate(d, "bookmarks", OrderNewest, page)
+	if err != nil {
+		return nil, "", err
+	}
+	var bs []model.Bookmark
+	if err = d.Select("bookmarks.*").Preload("Article").Preload("Article.Tags").Find(&bs).Error; err != nil {
+		return nil, "", err
+	}
+	token, n := nextPageToken(page, OrderNewest, len(bs), func(i int) cursor {
+		return cursor{Time: bs[i].CreatedAt, ID: bs[i].ID}
+	})
+	return bs[:n], token, nil
+}
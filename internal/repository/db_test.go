@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gosimple/slug"
+	"github.com/jinzhu/gorm"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+// createTestArticle creates a published article of the user with the given tags
+func createTestArticle(t *testing.T, db *gorm.DB, title, userID string, tags ...string) *model.Article {
+	t.Helper()
+	ts, err := NewORMTagRepository(db).Resolve(context.Background(), tags)
+	if err != nil {
+		t.Fatalf("resolve tags %v: %v", tags, err)
+	}
+	article := &model.Article{
+		Title:       title,
+		Slug:        slug.Make(title),
+		Description: "description of " + title,
+		Body:        "body of " + title,
+		UserID:      userID,
+		Tags:        ts,
+		Status:      model.StatusPublished,
+	}
+	if err = NewORMArticleRepository(db).Create(context.Background(), article); err != nil {
+		t.Fatalf("create article %s: %v", title, err)
+	}
+	return article
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/testdb"
+)
+
+func TestConcurrentFavorites(t *testing.T) {
+	const users = 8
+	tests := []struct {
+		name string
+		// favorited reports whether the u-th user has favorited the article before
+		favorited func(u int) bool
+		// favorite reports whether the u-th user favorites or unfavorites the article
+		favorite  func(u int) bool
+		wantCount int32
+	}{
+		{
+			name:      "favorite",
+			favorited: func(u int) bool { return false },
+			favorite:  func(u int) bool { return true },
+			wantCount: users,
+		},
+		{
+			name:      "unfavorite",
+			favorited: func(u int) bool { return true },
+			favorite:  func(u int) bool { return false },
+			wantCount: 0,
+		},
+		{
+			name:      "mixed",
+			favorited: func(u int) bool { return u%2 == 1 },
+			favorite:  func(u int) bool { return u%2 == 0 },
+			wantCount: users / 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := testdb.Open(t)
+			repo := NewORMArticleRepository(db)
+			article := createTestArticle(t, db, "Favorite "+tt.name, "author")
+			for u := 0; u < users; u++ {
+				if !tt.favorited(u) {
+					continue
+				}
+				if err := repo.AddFavorite(context.Background(), article, fmt.Sprintf("user-%d", u)); err != nil {
+					t.Fatalf("AddFavorite: %v", err)
+				}
+			}
+
+			// every user favorites or unfavorites the article several times at once
+			const n = 32
+			var wg sync.WaitGroup
+			errs := make(chan error, n)
+			for i := 0; i < n; i++ {
+				wg.Add(1)
+				go func(u int, a model.Article) {
+					defer wg.Done()
+					userID := fmt.Sprintf("user-%d", u)
+					if tt.favorite(u) {
+						errs <- repo.AddFavorite(context.Background(), &a, userID)
+					} else {
+						errs <- repo.DeleteFavorite(context.Background(), &a, userID)
+					}
+				}(i%users, *article)
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				if err != nil {
+					t.Errorf("favorite: %v", err)
+				}
+			}
+
+			var rows int32
+			err := db.Model(&model.FavoriteArticle{}).Where("article_id = ?", article.ID).Count(&rows).Error
+			if err != nil {
+				t.Fatalf("count favorites: %v", err)
+			}
+			var got model.Article
+			if err = db.First(&got, article.ID).Error; err != nil {
+				t.Fatalf("get article: %v", err)
+			}
+			if rows != tt.wantCount || got.FavoritesCount != tt.wantCount {
+				t.Errorf("favorite_articles rows = %d, favorites_count = %d, want %d", rows, got.FavoritesCount, tt.wantCount)
+			}
+		})
+	}
+}
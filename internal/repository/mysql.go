@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	// MySQL error of a statement violating a unique index
+	mysqlDuplicateEntry = 1062
+	// MySQL error of a statement waiting too long for a row lock
+	mysqlLockWaitTimeout = 1205
+	// MySQL error of a transaction rolled back to resolve a deadlock
+	mysqlDeadlock = 1213
+	// number of times a transaction is run when it is rolled back by a lock conflict
+	maxTxAttempts = 3
+)
+
+// isLockConflict reports whether err rolled back a transaction which can be run again
+func isLockConflict(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) &&
+		(mysqlErr.Number == mysqlDeadlock || mysqlErr.Number == mysqlLockWaitTimeout)
+}
+
+// retryTx runs fn in a transaction, which is committed when fn succeeds, and runs it
+// again when a deadlock or a lock wait timeout rolls it back
+func retryTx(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		tx := db.Begin()
+		if err = fn(tx); err == nil {
+			err = tx.Commit().Error
+		} else {
+			tx.Rollback()
+		}
+		if !isLockConflict(err) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ErrInvalidPageToken is returned for page tokens which were not created by the same listing
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// Page selects a page of a listing, after the cursor in Token if it's set or by Offset otherwise
+type Page struct {
+	Limit  int64
+	Offset int64
+	Token  string
+}
+
+// Order is a keyset order of a listing on a column with the id as tie-breaker
+type Order struct {
+	name   string
+	column string
+	desc   bool
+	// count orders are on integer columns, the others on time columns
+	count bool
+}
+
+// Listing orders
+var (
+	OrderNewest          = Order{name: "newest", column: "created_at", desc: true}
+	OrderOldest          = Order{name: "oldest", column: "created_at"}
+	OrderMostFavorited   = Order{name: "favorited", column: "favorites_count", desc: true, count: true}
+	OrderMostCommented   = Order{name: "commented", column: "comments_count", desc: true, count: true}
+	OrderRecentlyUpdated = Order{name: "updated", column: "updated_at", desc: true}
+	OrderMostViewed      = Order{name: "viewed", column: "views_count", desc: true, count: true}
+)
+
+// cursor is the position of the last item of a page in an ordered listing
+type cursor struct {
+	Order string    `json:"o"`
+	Time  time.Time `json:"t"`
+	Count int64     `json:"n"`
+	ID    uint      `json:"i"`
+}
+
+func (o Order) value(c *cursor) interface{} {
+	if o.count {
+		return c.Count
+	}
+	return c.Time
+}
+
+func encodeCursor(c cursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(token string, order Order) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var c cursor
+	if err = json.Unmarshal(b, &c); err != nil || c.ID == 0 || c.Order != order.name {
+		return nil, ErrInvalidPageToken
+	}
+	return &c, nil
+}
+
+// paginate orders the query on the table and selects the page,
+// one extra row is fetched to know whether there is a next page
+func paginate(d *gorm.DB, table string, order Order, page Page) (*gorm.DB, error) {
+	dir, cmp := "ASC", ">"
+	if order.desc {
+		dir, cmp = "DESC", "<"
+	}
+	d = d.Order(fmt.Sprintf("%[1]s.%[2]s %[3]s, %[1]s.id %[3]s", table, order.column, dir))
+	if page.Token != "" {
+		c, err := decodeCursor(page.Token, order)
+		if err != nil {
+			return nil, err
+		}
+		v := order.value(c)
+		d = d.Where(
+			fmt.Sprintf("%[1]s.%[2]s %[3]s ? OR (%[1]s.%[2]s = ? AND %[1]s.id %[3]s ?)", table, order.column, cmp),
+			v, v, c.ID,
+		)
+	} else if page.Offset > 0 {
+		d = d.Offset(page.Offset)
+	}
+	if page.Limit > 0 {
+		d = d.Limit(page.Limit + 1)
+	}
+	return d, nil
+}
+
+// nextPageToken returns the token of the page after the fetched rows, if there is one,
+// along with the number of rows which belong to the current page
+func nextPageToken(page Page, order Order, n int, last func(i int) cursor) (string, int) {
+	if page.Limit <= 0 || int64(n) <= page.Limit {
+		return "", n
+	}
+	n = int(page.Limit)
+	c := last(n - 1)
+	c.Order = order.name
+	return encodeCursor(c), n
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+type ReactionRepository interface {
+	Add(ctx context.Context, reaction *model.Reaction) error
+	Remove(ctx context.Context, reaction *model.Reaction) error
+	ArticleCounts(ctx context.Context, articleIDs []uint) (map[uint]map[string]int32, error)
+	CommentCounts(ctx context.Context, commentIDs []uint) (map[uint]map[string]int32, error)
+	ArticleReactions(ctx context.Context, articleIDs []uint, userID string) (map[uint][]string, error)
+	CommentReactions(ctx context.Context, commentIDs []uint, userID string) (map[uint][]string, error)
+}
+
+type ORMReactionRepository struct {
+	db *gorm.DB
+}
+
+func NewORMReactionRepository(db *gorm.DB) *ORMReactionRepository {
+	return &ORMReactionRepository{db: db}
+}
+
+// Add adds the reaction and increases its count, adding it again is a no-op
+func (repo *ORMReactionRepository) Add(ctx context.Context, reaction *model.Reaction) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReactionRepository.Add")
+	defer span.Finish()
+
+	tx := repo.db.Begin()
+	res := tx.Set("gorm:insert_modifier", "IGNORE").Create(reaction)
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		err := tx.Exec(
+			"INSERT INTO reaction_counts (article_id, comment_id, name, count) VALUES (?, ?, ?, 1) "+
+				"ON DUPLICATE KEY UPDATE count = count + 1",
+			reaction.ArticleID, reaction.CommentID, reaction.Name,
+		).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// Remove removes the reaction and decreases its count, removing a missing reaction is a no-op
+func (repo *ORMReactionRepository) Remove(ctx context.Context, reaction *model.Reaction) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReactionRepository.Remove")
+	defer span.Finish()
+
+	tx := repo.db.Begin()
+	res := tx.Where(
+		"user_id = ? AND article_id = ? AND comment_id = ? AND name = ?",
+		reaction.UserID, reaction.ArticleID, reaction.CommentID, reaction.Name,
+	).Delete(&model.Reaction{})
+	if res.Error != nil {
+		tx.Rollback()
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		err := tx.Model(&model.ReactionCount{}).
+			Where("article_id = ? AND comment_id = ? AND name = ? AND count > 0", reaction.ArticleID, reaction.CommentID, reaction.Name).
+			UpdateColumn("count", gorm.Expr("count - ?", 1)).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// ArticleCounts returns the reaction counts of the articles by article id
+func (repo *ORMReactionRepository) ArticleCounts(ctx context.Context, articleIDs []uint) (map[uint]map[string]int32, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReactionRepository.ArticleCounts")
+	defer span.Finish()
+
+	counts := make(map[uint]map[string]int32, len(articleIDs))
+	if len(articleIDs) == 0 {
+		return counts, nil
+	}
+	var rcs []model.ReactionCount
+	err := repo.db.Where("article_id IN (?) AND comment_id = 0 AND count > 0", articleIDs).Find(&rcs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, rc := range rcs {
+		if counts[rc.ArticleID] == nil {
+			counts[rc.ArticleID] = map[string]int32{}
+		}
+		counts[rc.ArticleID][rc.Name] = rc.Count
+	}
+	return counts, nil
+}
+
+// CommentCounts returns the reaction counts of the comments by comment id
+func (repo *ORMReactionRepository) CommentCounts(ctx context.Context, commentIDs []uint) (map[uint]map[string]int32, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReactionRepository.CommentCounts")
+	defer span.Finish()
+
+	counts := make(map[uint]map[string]int32, len(commentIDs))
+	if len(commentIDs) == 0 {
+		return counts, nil
+	}
+	var rcs []model.ReactionCount
+	err := repo.db.Where("comment_id IN (?) AND count > 0", commentIDs).Find(&rcs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, rc := range rcs {
+		if counts[rc.CommentID] == nil {
+			counts[rc.CommentID] = map[string]int32{}
+		}
+		counts[rc.CommentID][rc.Name] = rc.Count
+	}
+	return counts, nil
+}
+
+// ArticleReactions returns the reactions of the user to the articles by article id
+func (repo *ORMReactionRepository) ArticleReactions(ctx context.Context, articleIDs []uint, userID string) (map[uint][]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReactionRepository.ArticleReactions")
+	defer span.Finish()
+
+	reacted := make(map[uint][]string, len(articleIDs))
+	if len(articleIDs) == 0 || userID == "" {
+		return reacted, nil
+	}
+	var rs []model.Reaction
+	err := repo.db.Where("user_id = ? AND article_id IN (?) AND comment_id = 0", userID, articleIDs).
+		Order("id").
+		Find(&rs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rs {
+		reacted[r.ArticleID] = append(reacted[r.ArticleID], r.Name)
+	}
+	return reacted, nil
+}
+
+// CommentReactions returns the reactions of the user to the comments by comment id
+func (repo *ORMReactionRepository) CommentReactions(ctx context.Context, commentIDs []uint, userID string) (map[uint][]string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReactionRepository.CommentReactions")
+	defer span.Finish()
+
+	reacted := make(map[uint][]string, len(commentIDs))
+	if len(commentIDs) == 0 || userID == "" {
+		return reacted, nil
+	}
+	var rs []model.Reaction
+	err := repo.db.Where("user_id = ? AND comment_id IN (?)", userID, commentIDs).
+		Order("id").
+		Find(&rs).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rs {
+		reacted[r.CommentID] = append(reacted[r.CommentID], r.Name)
+	}
+	return reacted, nil
+}
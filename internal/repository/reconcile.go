@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+// Denormalized article counters
+const (
+	CounterFavorites = "favorites_count"
+	CounterComments  = "comments_count"
+)
+
+// counterSources count the rows each article counter is denormalized from
+var counterSources = map[string]string{
+	CounterFavorites: "SELECT COUNT(*) FROM favorite_articles " +
+		"WHERE favorite_articles.article_id = articles.id AND favorite_articles.deleted_at IS NULL",
+	CounterComments: "SELECT COUNT(*) FROM comments " +
+		"WHERE comments.article_id = articles.id AND comments.deleted_at IS NULL",
+}
+
+// CounterDrift is a stored article counter which differs from the count of its source rows
+type CounterDrift struct {
+	ArticleID uint
+	Counter   string
+	Stored    int64
+	Actual    int64
+}
+
+// articleCounters are the stored and recomputed counters of an article
+type articleCounters struct {
+	ID              uint
+	FavoritesCount  int64
+	ActualFavorites int64
+	CommentsCount   int64
+	ActualComments  int64
+}
+
+// ReconcileCounters compares the counters of a batch of articles, deleted ones included,
+// with their source rows and fixes the drifted ones if fix is set,
+// it returns the drifts and the last article id of the batch, which is 0 after the last batch
+func (repo *ORMArticleRepository) ReconcileCounters(ctx context.Context, afterID uint, limit int, fix bool) ([]CounterDrift, uint, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.ReconcileCounters")
+	defer span.Finish()
+
+	var acs []articleCounters
+	err := repo.db.Unscoped().Model(&model.Article{}).
+		Select("articles.id, articles.favorites_count, articles.comments_count, "+
+			"("+counterSources[CounterFavorites]+") AS actual_favorites, "+
+			"("+counterSources[CounterComments]+") AS actual_comments").
+		Where("articles.id > ?", afterID).
+		Order("articles.id").
+		Limit(limit).
+		Scan(&acs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var drifts []CounterDrift
+	for _, ac := range acs {
+		if ac.FavoritesCount != ac.ActualFavorites {
+			drifts = append(drifts, CounterDrift{ac.ID, CounterFavorites, ac.FavoritesCount, ac.ActualFavorites})
+		}
+		if ac.CommentsCount != ac.ActualComments {
+			drifts = append(drifts, CounterDrift{ac.ID, CounterComments, ac.CommentsCount, ac.ActualComments})
+		}
+	}
+	if fix {
+		for _, d := range drifts {
+			// recount in the update itself so changes since the comparison are not lost
+			err = repo.db.Unscoped().Model(&model.Article{}).
+				Where("id = ?", d.ArticleID).
+				UpdateColumn(d.Counter, gorm.Expr("("+counterSources[d.Counter]+")")).Error
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+	}
+
+	if len(acs) < limit {
+		return drifts, 0, nil
+	}
+	return drifts, acs[len(acs)-1].ID, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/pkg/utils"
+)
+
+type ReportRepository interface {
+	Create(ctx context.Context, report *model.Report) error
+	GetByID(ctx context.Context, id string) (*model.Report, error)
+	List(ctx context.Context, status string, page Page) ([]model.Report, string, error)
+	Resolve(ctx context.Context, report *model.Report, status, moderatorID string) error
+}
+
+type ORMReportRepository struct {
+	db *gorm.DB
+}
+
+func NewORMReportRepository(db *gorm.DB) *ORMReportRepository {
+	return &ORMReportRepository{db: db}
+}
+
+// Create records the report, a user reporting the same content again
+// while their report is open gets the existing report
+func (repo *ORMReportRepository) Create(ctx context.Context, report *model.Report) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReportRepository.Create")
+	defer span.Finish()
+
+	article := report.Article
+	var existing model.Report
+	err := reportTarget(repo.db, report).
+		Where("user_id = ? AND status = ?", report.UserID, model.ReportOpen).
+		First(&existing).Error
+	if err == nil {
+		*report = existing
+		report.Article = article
+		return nil
+	}
+	if !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+	return repo.db.Set("gorm:save_associations", false).Create(report).Error
+}
+
+func (repo *ORMReportRepository) GetByID(ctx context.Context, id string) (*model.Report, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReportRepository.GetByID")
+	defer span.Finish()
+
+	var r model.Report
+	if err := preloadReportArticle(repo.db).First(&r, utils.StringToUint(id)).Error; err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// List returns a page of the reports with the status, oldest first,
+// along with the token of the next page
+func (repo *ORMReportRepository) List(ctx context.Context, status string, page Page) ([]model.Report, string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReportRepository.List")
+	defer span.Finish()
+
+	d, err := paginate(repo.db.Where(model.Report{Status: status}), "reports", OrderOldest, page)
+	if err != nil {
+		return nil, "", err
+	}
+	var rs []model.Report
+	if err = preloadReportArticle(d).Find(&rs).Error; err != nil {
+		return nil, "", err
+	}
+	token, n := nextPageToken(page, OrderOldest, len(rs), func(i int) cursor {
+		return cursor{Time: rs[i].CreatedAt, ID: rs[i].ID}
+	})
+	return rs[:n], token, nil
+}
+
+// Resolve sets the status of the report and of every other open report of the same content
+func (repo *ORMReportRepository) Resolve(ctx context.Context, report *model.Report, status, moderatorID string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMReportRepository.Resolve")
+	defer span.Finish()
+
+	now := time.Now()
+	err := reportTarget(repo.db.Model(&model.Report{}), report).
+		Where("status = ? OR id = ?", model.ReportOpen, report.ID).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"resolved_by": moderatorID,
+			"resolved_at": now,
+		}).Error
+	if err != nil {
+		return err
+	}
+	report.Status = status
+	report.ResolvedBy = moderatorID
+	report.ResolvedAt = &now
+	return nil
+}
+
+// reportTarget selects the reports of the same content as the report
+func reportTarget(d *gorm.DB, report *model.Report) *gorm.DB {
+	d = d.Where("article_id = ?", report.ArticleID)
+	if report.CommentID == nil {
+		return d.Where("comment_id IS NULL")
+	}
+	return d.Where("comment_id = ?", *report.CommentID)
+}
+
+// preloadReportArticle loads the reported articles, even deleted ones
+func preloadReportArticle(d *gorm.DB) *gorm.DB {
+	return d.Preload("Article", func(d *gorm.DB) *gorm.DB {
+		return d.Unscoped()
+	})
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jinzhu/gorm"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+// createRevision stores the current content of the article as its next revision
+func createRevision(tx *gorm.DB, article *model.Article, userID string, fields []string) error {
+	var last struct{ Number int32 }
+	err := tx.Model(&model.ArticleRevision{}).
+		Select("COALESCE(MAX(number), 0) AS number").
+		Where("article_id = ?", article.ID).
+		Scan(&last).Error
+	if err != nil {
+		return err
+	}
+	rev := model.NewArticleRevision(article, userID, fields)
+	rev.Number = last.Number + 1
+	return tx.Create(&rev).Error
+}
+
+// createBaseRevision stores the persisted content of an article without revisions as revision one
+func createBaseRevision(tx *gorm.DB, articleID uint) error {
+	var count int
+	err := tx.Model(&model.ArticleRevision{}).Where("article_id = ?", articleID).Count(&count).Error
+	if err != nil || count > 0 {
+		return err
+	}
+	var a model.Article
+	if err = tx.Preload("Tags").First(&a, articleID).Error; err != nil {
+		return err
+	}
+	return createRevision(tx, &a, a.UserID, []string{})
+}
+
+func (repo *ORMArticleRepository) ListRevisions(ctx context.Context, article *model.Article, limit, offset int64) ([]model.ArticleRevision, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.ListRevisions")
+	defer span.Finish()
+
+	var rs []model.ArticleRevision
+	err := repo.db.Where(model.ArticleRevision{ArticleID: article.ID}).
+		Order("number DESC").
+		Offset(offset).Limit(limit).
+		Find(&rs).Error
+	if err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (repo *ORMArticleRepository) GetRevision(ctx context.Context, article *model.Article, number int32) (*model.ArticleRevision, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.GetRevision")
+	defer span.Finish()
+
+	var r model.ArticleRevision
+	err := repo.db.Where("article_id = ? AND number = ?", article.ID, number).First(&r).Error
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jinzhu/gorm"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+const (
+	// number of numeric suffixes tried before falling back to a random suffix
+	maxSlugSuffix = 50
+	// number of slugs tried when concurrent transactions take the chosen ones first
+	maxSlugAttempts = 10
+)
+
+const slugSuffixChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// reservedSlugs are the routes under /articles which take precedence over an article slug
+var reservedSlugs = map[string]bool{
+	"feed":  true,
+	"trash": true,
+}
+
+// slugCandidate returns the i-th candidate slug for base, starting at 1 with base itself
+func slugCandidate(base string, i int) string {
+	switch {
+	case i == 1:
+		return base
+	case i <= maxSlugSuffix:
+		return fmt.Sprintf("%s-%d", base, i)
+	default:
+		return fmt.Sprintf("%s-%s", base, randomSlugSuffix(6))
+	}
+}
+
+// uniqueSlug returns the first candidate slug for base from the start-th one which is not used
+// by another article, either as its slug or as one of its old slugs, nor reserved for a route,
+// along with its position
+func uniqueSlug(tx *gorm.DB, base string, articleID uint, start int) (string, int, error) {
+	for i := start; ; i++ {
+		candidate := slugCandidate(base, i)
+		if reservedSlugs[candidate] {
+			continue
+		}
+		taken, err := slugTaken(tx, candidate, articleID)
+		if err != nil {
+			return "", 0, err
+		}
+		if !taken {
+			return candidate, i, nil
+		}
+	}
+}
+
+// saveUniqueSlug saves the article with the first unused slug for base,
+// a concurrent transaction may take the same slug between the check and the save,
+// in which case the unique index rejects the save and the next unused slug is tried
+func saveUniqueSlug(tx *gorm.DB, base string, articleID uint, save func(slug string) error) error {
+	next := 1
+	for attempt := 1; ; attempt++ {
+		slug, i, err := uniqueSlug(tx, base, articleID, next)
+		if err != nil {
+			return err
+		}
+		// MySQL rolls back only the failed statement, so the transaction can go on
+		err = save(slug)
+		if !isDuplicateSlug(err) || attempt == maxSlugAttempts {
+			return err
+		}
+		// the snapshot of the transaction still shows the slug as unused
+		next = i + 1
+	}
+}
+
+// isDuplicateSlug reports whether err is a violation of the unique slug index
+func isDuplicateSlug(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry &&
+		strings.Contains(mysqlErr.Message, "slug")
+}
+
+func slugTaken(tx *gorm.DB, slug string, articleID uint) (bool, error) {
+	var count int
+	err := tx.Unscoped().Model(&model.Article{}).
+		Where("slug = ? AND id <> ?", slug, articleID).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = tx.Unscoped().Model(&model.SlugAlias{}).
+		Where("slug = ? AND article_id <> ?", slug, articleID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func randomSlugSuffix(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = slugSuffixChars[rand.Intn(len(slugSuffixChars))]
+	}
+	return string(b)
+}
+
+// updateSlug makes the new slug of the article unique and keeps the
+// previous slug resolvable as an alias
+func updateSlug(tx *gorm.DB, article *model.Article) error {
+	var stored model.Article
+	if err := tx.Select("id, slug").First(&stored, article.ID).Error; err != nil {
+		return err
+	}
+	if stored.Slug == article.Slug {
+		return nil
+	}
+	// the new slug is saved right away so a concurrent taker of it is detected here
+	err := saveUniqueSlug(tx, article.Slug, article.ID, func(slug string) error {
+		article.Slug = slug
+		return tx.Model(&model.Article{}).Where("id = ?", article.ID).UpdateColumn("slug", slug).Error
+	})
+	if err != nil {
+		return err
+	}
+	if stored.Slug == article.Slug {
+		return nil
+	}
+	// the article may take back one of its own old slugs
+	err = tx.Unscoped().
+		Where("slug = ? AND article_id = ?", article.Slug, article.ID).
+		Delete(&model.SlugAlias{}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Create(&model.SlugAlias{Slug: stored.Slug, ArticleID: article.ID}).Error
+}
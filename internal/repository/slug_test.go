@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/testdb"
+)
+
+func TestConcurrentCreateSameSlug(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewORMArticleRepository(db)
+
+	const n = 5
+	var wg sync.WaitGroup
+	slugs := make(chan string, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			article := &model.Article{
+				Title:       "Same title",
+				Slug:        "same-title",
+				Description: "description",
+				Body:        "body",
+				UserID:      "author",
+				Status:      model.StatusPublished,
+			}
+			if err := repo.Create(context.Background(), article); err != nil {
+				t.Errorf("Create: %v", err)
+				return
+			}
+			slugs <- article.Slug
+		}()
+	}
+	wg.Wait()
+	close(slugs)
+
+	seen := map[string]bool{}
+	for slug := range slugs {
+		if seen[slug] {
+			t.Errorf("slug %s used twice", slug)
+		}
+		seen[slug] = true
+	}
+	if len(seen) != n {
+		t.Errorf("created %d articles, want %d", len(seen), n)
+	}
+}
+
+func TestCreateReservedSlug(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewORMArticleRepository(db)
+
+	for _, slug := range []string{"feed", "trash"} {
+		article := &model.Article{
+			Title:       slug,
+			Slug:        slug,
+			Description: "description",
+			Body:        "body",
+			UserID:      "author",
+			Status:      model.StatusPublished,
+		}
+		if err := repo.Create(context.Background(), article); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if want := slug + "-2"; article.Slug != want {
+			t.Errorf("slug = %s, want %s", article.Slug, want)
+		}
+	}
+}
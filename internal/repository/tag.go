@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jinzhu/gorm"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+// ErrTagNameTaken is returned when a tag name is already used by a tag or an alias
+var ErrTagNameTaken = errors.New("tag name is already used")
+
+type TagRepository interface {
+	Resolve(ctx context.Context, names []string) ([]model.Tag, error)
+	GetTags(ctx context.Context) ([]model.TagCount, error)
+	GetByName(ctx context.Context, name string) (*model.Tag, error)
+	Count(ctx context.Context, tag *model.Tag) (*model.TagCount, error)
+	Rename(ctx context.Context, tag *model.Tag, name string) error
+	Merge(ctx context.Context, source, target *model.Tag) error
+	AddAlias(ctx context.Context, tag *model.Tag, alias string) error
+}
+
+type ORMTagRepository struct {
+	db *gorm.DB
+}
+
+func NewORMTagRepository(db *gorm.DB) *ORMTagRepository {
+	return &ORMTagRepository{db: db}
+}
+
+// Resolve returns the tags with the given names after normalizing them, aliases resolve
+// to their tags and missing tags are returned unsaved, the article repository creates them
+// along with the article so a failed save leaves no unused tags behind
+func (repo *ORMTagRepository) Resolve(ctx context.Context, names []string) ([]model.Tag, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMTagRepository.Resolve")
+	defer span.Finish()
+
+	normalized := normalizeTagNames(names)
+	if len(normalized) == 0 {
+		return []model.Tag{}, nil
+	}
+
+	var aliases []model.TagAlias
+	if err := repo.db.Where("name IN (?)", normalized).Find(&aliases).Error; err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(aliases))
+	for _, a := range aliases {
+		ids = append(ids, a.TagID)
+	}
+	var tags []model.Tag
+	err := repo.db.Where("name IN (?) OR id IN (?)", normalized, ids).Find(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[string]bool, len(tags)+len(aliases))
+	for _, t := range tags {
+		found[t.Name] = true
+	}
+	for _, a := range aliases {
+		found[a.Name] = true
+	}
+	for _, n := range normalized {
+		if !found[n] {
+			tags = append(tags, model.Tag{Name: n})
+		}
+	}
+	return tags, nil
+}
+
+// GetTags returns every tag with the number of published articles using it
+func (repo *ORMTagRepository) GetTags(ctx context.Context) ([]model.TagCount, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMTagRepository.GetTags")
+	defer span.Finish()
+
+	var tcs []model.TagCount
+	err := tagCounts(repo.db).
+		Order("articles_count DESC, tags.name").
+		Scan(&tcs).Error
+	if err != nil {
+		return nil, err
+	}
+	return tcs, nil
+}
+
+func (repo *ORMTagRepository) GetByName(ctx context.Context, name string) (*model.Tag, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMTagRepository.GetByName")
+	defer span.Finish()
+
+	var t model.Tag
+	err := repo.db.Where(model.Tag{Name: model.NormalizeTagName(name)}).First(&t).Error
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (repo *ORMTagRepository) Count(ctx context.Context, tag *model.Tag) (*model.TagCount, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMTagRepository.Count")
+	defer span.Finish()
+
+	var tc model.TagCount
+	err := tagCounts(repo.db).Where("tags.id = ?", tag.ID).Scan(&tc).Error
+	if err != nil {
+		return nil, err
+	}
+	return &tc, nil
+}
+
+// Rename renames the tag, its previous name becomes an alias of it
+func (repo *ORMTagRepository) Rename(ctx context.Context, tag *model.Tag, name string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMTagRepository.Rename")
+	defer span.Finish()
+
+	name = model.NormalizeTagName(name)
+	if name == tag.Name {
+		return nil
+	}
+
+	tx := repo.db.Begin()
+	taken, err := tagNameTaken(tx, name, tag.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if taken {
+		tx.Rollback()
+		return ErrTagNameTaken
+	}
+	// the tag may take back one of its own aliases
+	err = tx.Unscoped().Where("name = ? AND tag_id = ?", name, tag.ID).Delete(&model.TagAlias{}).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Create(&model.TagAlias{Name: tag.Name, TagID: tag.ID}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Model(tag).Update("name", name).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// Merge moves the articles and aliases of the source tag to the target tag,
+// deletes the source tag and keeps its name as an alias of the target
+func (repo *ORMTagRepository) Merge(ctx context.Context, source, target *model.Tag) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMTagRepository.Merge")
+	defer span.Finish()
+
+	tx := repo.db.Begin()
+	err := tx.Exec(
+		"INSERT IGNORE INTO article_tags (article_id, tag_id) SELECT article_id, ? FROM article_tags WHERE tag_id = ?",
+		target.ID, source.ID,
+	).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Exec("DELETE FROM article_tags WHERE tag_id = ?", source.ID).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = tx.Model(&model.TagAlias{}).Where("tag_id = ?", source.ID).Update("tag_id", target.ID).Error
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Unscoped().Delete(source).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = tx.Create(&model.TagAlias{Name: source.Name, TagID: target.ID}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// AddAlias declares the alias as a synonym of the tag
+func (repo *ORMTagRepository) AddAlias(ctx context.Context, tag *model.Tag, alias string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMTagRepository.AddAlias")
+	defer span.Finish()
+
+	alias = model.NormalizeTagName(alias)
+	tx := repo.db.Begin()
+	taken, err := tagNameTaken(tx, alias, 0)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if taken {
+		tx.Rollback()
+		return ErrTagNameTaken
+	}
+	if err = tx.Create(&model.TagAlias{Name: alias, TagID: tag.ID}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// tagCounts selects tags with the number of published articles using them
+func tagCounts(db *gorm.DB) *gorm.DB {
+	return db.Table("tags").
+		Select("tags.name, COUNT(articles.id) AS articles_count").
+		Joins("LEFT JOIN article_tags ON article_tags.tag_id = tags.id").
+		Joins("LEFT JOIN articles ON articles.id = article_tags.article_id AND articles.deleted_at IS NULL AND articles.status = ?", model.StatusPublished).
+		Where("tags.deleted_at IS NULL").
+		Group("tags.id, tags.name")
+}
+
+// taggedArticleIDs selects the ids of articles tagged with any of the names
+// or with a tag the names are aliases of
+func taggedArticleIDs(db *gorm.DB, names []string) *gorm.SqlExpr {
+	names = normalizeTagNames(names)
+	return db.Table("article_tags").
+		Select("article_tags.article_id").
+		Where("article_tags.tag_id IN (?)", tagIDs(db, names)).
+		SubQuery()
+}
+
+// tagIDs selects the ids of the tags with the names or with aliases named so
+func tagIDs(db *gorm.DB, names []string) *gorm.SqlExpr {
+	aliased := db.Model(&model.TagAlias{}).Select("tag_id").Where("name IN (?)", names).SubQuery()
+	return db.Model(&model.Tag{}).Select("id").Where("name IN (?) OR id IN (?)", names, aliased).SubQuery()
+}
+
+// tagNameTaken reports whether the name is used by another tag than tagID or by an alias of another tag
+func tagNameTaken(tx *gorm.DB, name string, tagID uint) (bool, error) {
+	var count int
+	err := tx.Model(&model.Tag{}).Where("name = ? AND id <> ?", name, tagID).Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = tx.Model(&model.TagAlias{}).Where("name = ? AND tag_id <> ?", name, tagID).Count(&count).Error
+	return count > 0, err
+}
+
+// createTags creates the unsaved tags in the transaction and sets their ids
+func createTags(tx *gorm.DB, tags []model.Tag) error {
+	for i := range tags {
+		if tags[i].ID != 0 {
+			continue
+		}
+		// the unique name index makes concurrent creation of the same tag a no-op
+		err := tx.Set("gorm:insert_modifier", "IGNORE").Create(&model.Tag{Name: tags[i].Name}).Error
+		if err != nil {
+			return err
+		}
+		// a locking read sees the tag created by a concurrent transaction
+		err = tx.Set("gorm:query_option", "FOR SHARE").Where("name = ?", tags[i].Name).First(&tags[i]).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeTagNames normalizes the names and drops empty and duplicated ones
+func normalizeTagNames(names []string) []string {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, n := range names {
+		n = model.NormalizeTagName(n)
+		if n == "" || seen[n] {
+			continue
+		}
+		seen[n] = true
+		normalized = append(normalized, n)
+	}
+	return normalized
+}
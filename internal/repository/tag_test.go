@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+	"github.com/rezaAmiri123/service-article/internal/testdb"
+)
+
+func TestResolveCreatesTagsWithArticle(t *testing.T) {
+	db := testdb.Open(t)
+	repo := NewORMTagRepository(db)
+
+	tags, err := repo.Resolve(context.Background(), []string{"C", "C++", " c# ", "c"})
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if len(tags) != 3 {
+		t.Fatalf("tags = %v, want c, c++ and c#", tags)
+	}
+	var count int
+	if err = db.Model(&model.Tag{}).Count(&count).Error; err != nil {
+		t.Fatalf("count tags: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Resolve created %d tags, want them created with the article", count)
+	}
+
+	article := createTestArticle(t, db, "Languages", "author", "C", "C++", " c# ", "c")
+	if len(article.Tags) != 3 {
+		t.Fatalf("article tags = %v, want c, c++ and c#", article.Tags)
+	}
+	for _, tag := range article.Tags {
+		if tag.ID == 0 {
+			t.Errorf("tag %s has not been created", tag.Name)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/opentracing/opentracing-go"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+func (repo *ORMArticleRepository) GetDeletedBySlug(ctx context.Context, slug string) (*model.Article, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.GetDeletedBySlug")
+	defer span.Finish()
+
+	var a model.Article
+	err := repo.db.Unscoped().
+		Preload("Tags").
+		Where("slug = ? AND deleted_at IS NOT NULL", slug).
+		First(&a).Error
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (repo *ORMArticleRepository) ListDeleted(ctx context.Context, userID string, limit, offset int64) ([]model.Article, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.ListDeleted")
+	defer span.Finish()
+
+	var as []model.Article
+	err := deletedArticles(repo.db, userID).
+		Preload("Tags").
+		Order("deleted_at DESC").
+		Offset(offset).Limit(limit).
+		Find(&as).Error
+	return as, err
+}
+
+// CountDeleted returns the number of deleted articles of the user across all pages
+func (repo *ORMArticleRepository) CountDeleted(ctx context.Context, userID string) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.CountDeleted")
+	defer span.Finish()
+
+	var count int64
+	err := deletedArticles(repo.db, userID).Count(&count).Error
+	return count, err
+}
+
+// deletedArticles selects the deleted articles of the user
+func deletedArticles(db *gorm.DB, userID string) *gorm.DB {
+	return db.Unscoped().
+		Model(&model.Article{}).
+		Where("user_id = ? AND deleted_at IS NOT NULL", userID)
+}
+
+func (repo *ORMArticleRepository) Restore(ctx context.Context, article *model.Article) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.Restore")
+	defer span.Finish()
+
+	return repo.db.Unscoped().Model(article).Update("deleted_at", nil).Error
+}
+
+// Purge permanently deletes the article with everything which belongs to it
+func (repo *ORMArticleRepository) Purge(ctx context.Context, article *model.Article) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.Purge")
+	defer span.Finish()
+
+	tx := repo.db.Begin()
+	if err := purgeArticles(tx, []uint{article.ID}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// PurgeDeletedBefore permanently deletes up to limit articles which were deleted before the given time
+func (repo *ORMArticleRepository) PurgeDeletedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ORMArticleRepository.PurgeDeletedBefore")
+	defer span.Finish()
+
+	tx := repo.db.Begin()
+	var ids []uint
+	err := tx.Unscoped().
+		Set("gorm:query_option", "FOR UPDATE SKIP LOCKED").
+		Model(&model.Article{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if len(ids) == 0 {
+		tx.Rollback()
+		return 0, nil
+	}
+	if err = purgeArticles(tx, ids); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	if err = tx.Commit().Error; err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// purgeArticles hard deletes the articles and their comments, favorites,
+// tag links, revisions and slug aliases
+func purgeArticles(tx *gorm.DB, ids []uint) error {
+	err := tx.Unscoped().
+		Where("comment_id IN (?)", tx.Unscoped().Model(&model.Comment{}).Select("id").Where("article_id IN (?)", ids).SubQuery()).
+		Delete(&model.CommentRevision{}).Error
+	if err != nil {
+		return err
+	}
+	dependents := []interface{}{
+		&model.Comment{},
+		&model.FavoriteArticle{},
+		&model.ArticleRevision{},
+		&model.SlugAlias{},
+		&model.Report{},
+		&model.Reaction{},
+		&model.ReactionCount{},
+		&model.Bookmark{},
+	}
+	for _, d := range dependents {
+		if err := tx.Unscoped().Where("article_id IN (?)", ids).Delete(d).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Exec("DELETE FROM article_tags WHERE article_id IN (?)", ids).Error; err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN (?)", ids).Delete(&model.Article{}).Error
+}
@@ -0,0 +1,44 @@
+// Package testdb connects tests to a MySQL database
+package testdb
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	_ "github.com/jinzhu/gorm/dialects/mysql"
+
+	"github.com/rezaAmiri123/service-article/internal/model"
+)
+
+// tables are emptied before each test using the database
+var tables = []string{
+	"articles", "article_tags", "tags", "tag_aliases", "slug_aliases", "article_revisions",
+	"comments", "comment_revisions", "favorite_articles", "reports", "reactions", "reaction_counts", "bookmarks",
+}
+
+// Open connects to the MySQL database of TEST_MYSQL_DSN, migrates it and empties its tables,
+// the test is skipped when TEST_MYSQL_DSN is not set, e.g.
+// TEST_MYSQL_DSN="root:secret@tcp(localhost:3306)/article_test?charset=utf8&parseTime=True&loc=Local"
+func Open(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+	db, err := gorm.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("connect to the database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err = model.AutoMigrate(db); err != nil {
+		t.Fatalf("migrate the database: %v", err)
+	}
+	for _, table := range tables {
+		if err = db.Exec("TRUNCATE TABLE " + table).Error; err != nil {
+			t.Fatalf("truncate %s: %v", table, err)
+		}
+	}
+	return db
+}
@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rezaAmiri123/service-article/cmd/config"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	"github.com/rezaAmiri123/service-article/pkg/logger"
+)
+
+const (
+	defaultPublisherInterval  = 30 * time.Second
+	defaultPublisherBatchSize = 100
+)
+
+// Publisher publishes scheduled articles once they are due
+type Publisher struct {
+	repo      repository.ArticleRepository
+	logger    logger.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+// NewPublisher creates a publisher from the publisher config
+func NewPublisher(cfg *config.Config, repo repository.ArticleRepository, logger logger.Logger) *Publisher {
+	p := &Publisher{
+		repo:      repo,
+		logger:    logger,
+		interval:  cfg.Publisher.Interval * time.Second,
+		batchSize: cfg.Publisher.BatchSize,
+	}
+	if p.interval <= 0 {
+		p.interval = defaultPublisherInterval
+	}
+	if p.batchSize <= 0 {
+		p.batchSize = defaultPublisherBatchSize
+	}
+	return p
+}
+
+// Run publishes due articles every interval until ctx is canceled
+func (p *Publisher) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.publishDue(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Publisher) publishDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := p.repo.PublishDue(ctx, time.Now(), p.batchSize)
+		if err != nil {
+			p.logger.Errorf("failed to publish scheduled articles: %v", err)
+			return
+		}
+		if n > 0 {
+			p.logger.Infof("published %d scheduled articles", n)
+		}
+		if n < int64(p.batchSize) {
+			return
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rezaAmiri123/service-article/cmd/config"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	"github.com/rezaAmiri123/service-article/pkg/logger"
+)
+
+const defaultReconcileBatchSize = 500
+
+// Reconciler compares the denormalized article counters with their source tables,
+// it reports the drifted counters and fixes them unless it runs in dry-run mode
+type Reconciler struct {
+	repo      repository.ArticleRepository
+	logger    logger.Logger
+	interval  time.Duration
+	batchSize int
+	fix       bool
+}
+
+// NewReconciler creates a reconciler from the reconcile config
+func NewReconciler(cfg *config.Config, repo repository.ArticleRepository, logger logger.Logger) *Reconciler {
+	r := &Reconciler{
+		repo:      repo,
+		logger:    logger,
+		interval:  cfg.Reconcile.Interval * time.Minute,
+		batchSize: cfg.Reconcile.BatchSize,
+		fix:       cfg.Reconcile.Fix,
+	}
+	if r.batchSize <= 0 {
+		r.batchSize = defaultReconcileBatchSize
+	}
+	return r
+}
+
+// Run reconciles the counters every interval until ctx is canceled,
+// it returns right away if no interval is configured
+func (r *Reconciler) Run(ctx context.Context) {
+	if r.interval <= 0 {
+		r.logger.Info("reconcile interval is not configured, counters are not reconciled")
+		return
+	}
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Reconcile(ctx); err != nil {
+			r.logger.Errorf("failed to reconcile counters: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Reconcile checks the counters of every article once in batches and returns the drifted ones
+func (r *Reconciler) Reconcile(ctx context.Context) ([]repository.CounterDrift, error) {
+	var drifts []repository.CounterDrift
+	var afterID uint
+	for ctx.Err() == nil {
+		ds, lastID, err := r.repo.ReconcileCounters(ctx, afterID, r.batchSize, r.fix)
+		if err != nil {
+			return drifts, err
+		}
+		for _, d := range ds {
+			r.logger.Warnf("article %d %s is %d, counted %d", d.ArticleID, d.Counter, d.Stored, d.Actual)
+		}
+		drifts = append(drifts, ds...)
+		if lastID == 0 {
+			break
+		}
+		afterID = lastID
+	}
+	if len(drifts) > 0 {
+		action := "found"
+		if r.fix {
+			action = "fixed"
+		}
+		r.logger.Infof("%s %d drifted counters", action, len(drifts))
+	}
+	return drifts, ctx.Err()
+}
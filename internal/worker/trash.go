@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rezaAmiri123/service-article/cmd/config"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	"github.com/rezaAmiri123/service-article/pkg/logger"
+)
+
+const (
+	defaultTrashInterval  = time.Hour
+	defaultTrashBatchSize = 100
+)
+
+// TrashPurger permanently deletes articles which stayed in the trash
+// longer than the retention period
+type TrashPurger struct {
+	repo      repository.ArticleRepository
+	logger    logger.Logger
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+}
+
+// NewTrashPurger creates a trash purger from the trash config
+func NewTrashPurger(cfg *config.Config, repo repository.ArticleRepository, logger logger.Logger) *TrashPurger {
+	p := &TrashPurger{
+		repo:      repo,
+		logger:    logger,
+		retention: time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour,
+		interval:  cfg.Trash.Interval * time.Minute,
+		batchSize: cfg.Trash.BatchSize,
+	}
+	if p.interval <= 0 {
+		p.interval = defaultTrashInterval
+	}
+	if p.batchSize <= 0 {
+		p.batchSize = defaultTrashBatchSize
+	}
+	return p
+}
+
+// Run purges expired articles every interval until ctx is canceled,
+// it returns right away if no retention period is configured
+func (p *TrashPurger) Run(ctx context.Context) {
+	if p.retention <= 0 {
+		p.logger.Info("trash retention is not configured, deleted articles are kept")
+		return
+	}
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		p.purgeExpired(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) purgeExpired(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := p.repo.PurgeDeletedBefore(ctx, time.Now().Add(-p.retention), p.batchSize)
+		if err != nil {
+			p.logger.Errorf("failed to purge deleted articles: %v", err)
+			return
+		}
+		if n > 0 {
+			p.logger.Infof("purged %d deleted articles", n)
+		}
+		if n < int64(p.batchSize) {
+			return
+		}
+	}
+}
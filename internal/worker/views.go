@@ -0,0 +1,157 @@
+package worker
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/rezaAmiri123/service-article/cmd/config"
+	"github.com/rezaAmiri123/service-article/internal/repository"
+	"github.com/rezaAmiri123/service-article/pkg/logger"
+)
+
+const (
+	defaultViewsWindow        = 30 * time.Minute
+	defaultViewsFlushInterval = 10 * time.Second
+	defaultViewsMaxViewers    = 100000
+	// timeout of the last flush after ctx is canceled
+	viewsShutdownTimeout = 5 * time.Second
+)
+
+// ViewCounter counts article views once per viewer in the deduplication window,
+// views are buffered in memory and added to the articles in batches,
+// so a hot article is updated once per flush instead of once per view
+type ViewCounter struct {
+	repo          repository.ArticleRepository
+	logger        logger.Logger
+	window        time.Duration
+	flushInterval time.Duration
+	maxViewers    int
+
+	mu sync.Mutex
+	// seen is the entry in order of each viewer of an article counted within the window
+	seen map[viewerKey]*list.Element
+	// order holds the seen entries from the least to the most recently counted one
+	order *list.List
+	// pending is the number of views of each article which are not flushed yet
+	pending map[uint]int64
+}
+
+// viewerKey identifies a viewer of an article, the viewer is hashed so that
+// the size of an entry doesn't depend on the client
+type viewerKey struct {
+	articleID uint
+	viewer    [sha256.Size]byte
+}
+
+// seenViewer is the time a viewer of an article was last counted
+type seenViewer struct {
+	key viewerKey
+	at  time.Time
+}
+
+// NewViewCounter creates a view counter from the views config
+func NewViewCounter(cfg *config.Config, repo repository.ArticleRepository, logger logger.Logger) *ViewCounter {
+	c := &ViewCounter{
+		repo:          repo,
+		logger:        logger,
+		window:        cfg.Views.Window * time.Minute,
+		flushInterval: cfg.Views.FlushInterval * time.Second,
+		maxViewers:    cfg.Views.MaxViewers,
+		seen:          map[viewerKey]*list.Element{},
+		order:         list.New(),
+		pending:       map[uint]int64{},
+	}
+	if c.window <= 0 {
+		c.window = defaultViewsWindow
+	}
+	if c.flushInterval <= 0 {
+		c.flushInterval = defaultViewsFlushInterval
+	}
+	if c.maxViewers <= 0 {
+		c.maxViewers = defaultViewsMaxViewers
+	}
+	return c
+}
+
+// Record counts a view of the article by the viewer unless the viewer was counted
+// within the window, an empty viewer can't be deduplicated and is never counted,
+// it reports whether the view was counted,
+// beyond the maximum number of viewers the least recently counted one is forgotten
+func (c *ViewCounter) Record(articleID uint, viewer string) bool {
+	if viewer == "" {
+		return false
+	}
+	key := viewerKey{articleID: articleID, viewer: sha256.Sum256([]byte(viewer))}
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.seen[key]; ok {
+		if now.Sub(e.Value.(*seenViewer).at) < c.window {
+			return false
+		}
+		c.order.Remove(e)
+	}
+	c.seen[key] = c.order.PushBack(&seenViewer{key: key, at: now})
+	for len(c.seen) > c.maxViewers {
+		c.forget(c.order.Front())
+	}
+	c.pending[articleID]++
+	return true
+}
+
+// forget removes the seen entry of a viewer
+func (c *ViewCounter) forget(e *list.Element) {
+	delete(c.seen, e.Value.(*seenViewer).key)
+	c.order.Remove(e)
+}
+
+// Run flushes the buffered views every flush interval until ctx is canceled,
+// the remaining views are flushed before it returns
+func (c *ViewCounter) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), viewsShutdownTimeout)
+			c.flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			c.flush(ctx)
+		}
+	}
+}
+
+func (c *ViewCounter) flush(ctx context.Context) {
+	now := time.Now()
+	c.mu.Lock()
+	views := c.pending
+	c.pending = map[uint]int64{}
+	// the entries are in the order they were counted, so the expired ones come first
+	for e := c.order.Front(); e != nil; e = c.order.Front() {
+		if now.Sub(e.Value.(*seenViewer).at) < c.window {
+			break
+		}
+		c.forget(e)
+	}
+	c.mu.Unlock()
+
+	if len(views) == 0 {
+		return
+	}
+	if err := c.repo.AddViews(ctx, views); err != nil {
+		c.logger.Errorf("failed to flush views of %d articles: %v", len(views), err)
+		// keep the views for the next flush
+		c.mu.Lock()
+		for id, n := range views {
+			c.pending[id] += n
+		}
+		c.mu.Unlock()
+	}
+}
@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rezaAmiri123/service-article/cmd/config"
+)
+
+func TestViewCounterRecord(t *testing.T) {
+	c := NewViewCounter(&config.Config{}, nil, nil)
+
+	records := []struct {
+		articleID uint
+		viewer    string
+		want      bool
+	}{
+		{1, "user:42", true},
+		{1, "user:42", false},
+		{2, "user:42", true},
+		{1, "fp:abc", true},
+		{1, "", false},
+		{1, "", false},
+	}
+	for _, r := range records {
+		if got := c.Record(r.articleID, r.viewer); got != r.want {
+			t.Errorf("Record(%d, %q) = %v, want %v", r.articleID, r.viewer, got, r.want)
+		}
+	}
+	if c.pending[1] != 2 || c.pending[2] != 1 {
+		t.Errorf("pending = %v, want map[1:2 2:1]", c.pending)
+	}
+}
+
+func TestViewCounterMaxViewers(t *testing.T) {
+	c := NewViewCounter(&config.Config{Views: config.ViewsConfig{MaxViewers: 2}}, nil, nil)
+
+	records := []struct {
+		viewer string
+		want   bool
+	}{
+		{"user:1", true},
+		{"user:2", true},
+		{"user:1", false},
+		// forgets user:1, the least recently counted viewer
+		{"user:3", true},
+		{"user:2", false},
+		{"user:1", true},
+		{strings.Repeat("x", 1<<20), true},
+	}
+	for _, r := range records {
+		if got := c.Record(1, r.viewer); got != r.want {
+			t.Errorf("Record(1, %.10q) = %v, want %v", r.viewer, got, r.want)
+		}
+		if len(c.seen) > 2 || c.order.Len() != len(c.seen) {
+			t.Fatalf("%d seen viewers in %d entries, want at most 2", len(c.seen), c.order.Len())
+		}
+	}
+}
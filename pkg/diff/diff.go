@@ -0,0 +1,181 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// number of unchanged lines shown around each change
+	contextLines = 3
+	// maximum number of cells of the longest common subsequence table,
+	// the changed lines of both texts multiplied, so a diff stays within 16MB
+	maxTableCells = 4 << 20
+)
+
+// ErrTooLarge is returned when the changed parts of the texts are too large to diff
+var ErrTooLarge = errors.New("diff too large")
+
+type opKind byte
+
+const (
+	opEqual  opKind = ' '
+	opDelete opKind = '-'
+	opInsert opKind = '+'
+)
+
+type op struct {
+	kind opKind
+	line string
+	// positions of the line in the old and new text before this op
+	a, b int
+}
+
+// Unified returns a line-level unified diff between a and b,
+// it returns an empty string when they are equal and ErrTooLarge when too many lines changed
+func Unified(fromName, toName, a, b string) (string, error) {
+	if a == b {
+		return "", nil
+	}
+	ops, err := lineOps(splitLines(a), splitLines(b))
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for _, h := range hunks(ops) {
+		writeHunk(&sb, ops[h[0]:h[1]])
+	}
+	return sb.String(), nil
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// lineOps computes the edit script between a and b,
+// the common prefix and suffix are kept as is and the lines between them
+// are diffed by their longest common subsequence
+func lineOps(a, b []string) ([]op, error) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if int64(len(ma)+1)*int64(len(mb)+1) > maxTableCells {
+		return nil, ErrTooLarge
+	}
+
+	ops := make([]op, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, op{kind: opEqual, line: a[i], a: i, b: i})
+	}
+	ops = lcsOps(ops, ma, mb, prefix)
+	for k := 0; k < suffix; k++ {
+		i, j := len(a)-suffix+k, len(b)-suffix+k
+		ops = append(ops, op{kind: opEqual, line: a[i], a: i, b: j})
+	}
+	return ops, nil
+}
+
+// lcsOps appends the edit script between a and b from their longest common subsequence,
+// offset is the position of a and b in the whole texts
+func lcsOps(ops []op, a, b []string, offset int) []op {
+	// lcs[i*w+j] is the length of the longest common subsequence of a[i:] and b[j:]
+	w := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*w)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*w+j] = lcs[(i+1)*w+j+1] + 1
+			} else if lcs[(i+1)*w+j] >= lcs[i*w+j+1] {
+				lcs[i*w+j] = lcs[(i+1)*w+j]
+			} else {
+				lcs[i*w+j] = lcs[i*w+j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, op{kind: opEqual, line: a[i], a: offset + i, b: offset + j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[(i+1)*w+j] >= lcs[i*w+j+1]):
+			ops = append(ops, op{kind: opDelete, line: a[i], a: offset + i, b: offset + j})
+			i++
+		default:
+			ops = append(ops, op{kind: opInsert, line: b[j], a: offset + i, b: offset + j})
+			j++
+		}
+	}
+	return ops
+}
+
+// hunks groups changed ops with their context into [start, end) ranges
+func hunks(ops []op) [][2]int {
+	var result [][2]int
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == opEqual {
+			continue
+		}
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		// extend the hunk while the next change is close enough to share context
+		end, equal := i+1, 0
+		for k := i + 1; k < len(ops) && equal <= 2*contextLines; k++ {
+			if ops[k].kind == opEqual {
+				equal++
+				continue
+			}
+			end, equal = k+1, 0
+		}
+		i = end - 1
+		end += contextLines
+		if end > len(ops) {
+			end = len(ops)
+		}
+		if n := len(result); n > 0 && result[n-1][1] >= start {
+			result[n-1][1] = end
+			continue
+		}
+		result = append(result, [2]int{start, end})
+	}
+	return result
+}
+
+func writeHunk(sb *strings.Builder, ops []op) {
+	var aLen, bLen int
+	for _, o := range ops {
+		if o.kind != opInsert {
+			aLen++
+		}
+		if o.kind != opDelete {
+			bLen++
+		}
+	}
+	aStart, bStart := ops[0].a, ops[0].b
+	if aLen > 0 {
+		aStart++
+	}
+	if bLen > 0 {
+		bStart++
+	}
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen)
+	for _, o := range ops {
+		fmt.Fprintf(sb, "%c%s\n", o.kind, o.line)
+	}
+}
@@ -0,0 +1,110 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// numberedLines returns the lines "line from" to "line to",
+// the lines in change are replaced by their value or dropped when it is empty
+func numberedLines(from, to int, change map[int]string) string {
+	var sb strings.Builder
+	for i := from; i <= to; i++ {
+		if c, ok := change[i]; ok {
+			if c != "" {
+				sb.WriteString(c + "\n")
+			}
+			continue
+		}
+		fmt.Fprintf(&sb, "line %d\n", i)
+	}
+	return sb.String()
+}
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want string
+	}{
+		{
+			name: "equal",
+			a:    "a\nb\n",
+			b:    "a\nb\n",
+			want: "",
+		},
+		{
+			name: "changed line",
+			a:    "a\nb\nc\n",
+			b:    "a\nB\nc\n",
+			want: "--- from\n+++ to\n" +
+				"@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "from empty",
+			a:    "",
+			b:    "x\ny\n",
+			want: "--- from\n+++ to\n" +
+				"@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name: "to empty",
+			a:    "x\n",
+			b:    "",
+			want: "--- from\n+++ to\n" +
+				"@@ -1,1 +0,0 @@\n-x\n",
+		},
+		{
+			name: "appended line without trailing newline",
+			a:    "x\ny",
+			b:    "x\ny\nz\n",
+			want: "--- from\n+++ to\n" +
+				"@@ -1,2 +1,3 @@\n x\n y\n+z\n",
+		},
+		{
+			name: "distant changes",
+			a:    numberedLines(1, 20, nil),
+			b:    numberedLines(1, 20, map[int]string{2: "two", 18: "eighteen"}),
+			want: "--- from\n+++ to\n" +
+				"@@ -1,5 +1,5 @@\n line 1\n-line 2\n+two\n line 3\n line 4\n line 5\n" +
+				"@@ -15,6 +15,6 @@\n line 15\n line 16\n line 17\n-line 18\n+eighteen\n line 19\n line 20\n",
+		},
+		{
+			name: "close changes share a hunk",
+			a:    numberedLines(1, 20, nil),
+			b:    numberedLines(1, 20, map[int]string{5: "five", 10: ""}),
+			want: "--- from\n+++ to\n" +
+				"@@ -2,12 +2,11 @@\n line 2\n line 3\n line 4\n-line 5\n+five\n" +
+				" line 6\n line 7\n line 8\n line 9\n-line 10\n line 11\n line 12\n line 13\n",
+		},
+		{
+			name: "large texts with a small change",
+			a:    numberedLines(1, 100000, nil),
+			b:    numberedLines(1, 100000, map[int]string{50000: "changed"}),
+			want: "--- from\n+++ to\n" +
+				"@@ -49997,7 +49997,7 @@\n line 49997\n line 49998\n line 49999\n" +
+				"-line 50000\n+changed\n line 50001\n line 50002\n line 50003\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Unified("from", "to", tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Unified: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Unified =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedTooLarge(t *testing.T) {
+	a := numberedLines(1, 3000, nil)
+	b := strings.Replace(a, "line", "row", -1)
+	if _, err := Unified("from", "to", a, b); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Unified error = %v, want %v", err, ErrTooLarge)
+	}
+}